@@ -20,10 +20,276 @@ CREATE TABLE `sprints` (
   `user_id` bigint UNSIGNED NOT NULL,
   `name` varchar(255) NOT NULL,
   `description` varchar(255) DEFAULT NULL,
+  `goals` text DEFAULT NULL,
   `start` date NOT NULL,
   `end` date NOT NULL,
+  `start_time` time DEFAULT NULL,
+  `end_time` time DEFAULT NULL,
   `project_id` bigint UNSIGNED DEFAULT NULL,
+  `number` int UNSIGNED DEFAULT NULL,
+  `team_id` bigint UNSIGNED DEFAULT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  KEY (team_id),
+  UNIQUE KEY (project_id, number)
+);
+
+-- --------------------------------------------------------
+
+--
+-- Table structure for table `personal_access_tokens`
+--
+
+CREATE TABLE `personal_access_tokens` (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT,
+  `user_id` bigint UNSIGNED NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `token_hash` char(64) NOT NULL,
+  `scopes` varchar(1024) DEFAULT NULL,
+  `expires_at` DATETIME DEFAULT NULL,
+  `last_used_at` DATETIME DEFAULT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY (token_hash),
+  KEY (user_id)
+);
+
+-- --------------------------------------------------------
+
+--
+-- Table structure for table `sprint_shares`
+--
+
+CREATE TABLE `sprint_shares` (
+  `sprint_id` bigint UNSIGNED NOT NULL,
+  `user_id` bigint UNSIGNED NOT NULL,
+  `role` enum('viewer', 'editor') NOT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (sprint_id, user_id),
+  KEY (user_id),
+  FOREIGN KEY (sprint_id) REFERENCES sprints(id) ON DELETE CASCADE
+);
+
+
+-- --------------------------------------------------------
+
+--
+-- Table structure for table `teams`
+--
+
+CREATE TABLE `teams` (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT,
+  `name` varchar(255) NOT NULL,
   `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
   `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
   PRIMARY KEY (id)
-);
\ No newline at end of file
+);
+
+-- --------------------------------------------------------
+
+--
+-- Table structure for table `team_members`
+--
+
+CREATE TABLE `team_members` (
+  `team_id` bigint UNSIGNED NOT NULL,
+  `user_id` bigint UNSIGNED NOT NULL,
+  `role` enum('owner', 'admin', 'member') NOT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (team_id, user_id),
+  KEY (user_id),
+  FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE CASCADE
+);
+
+-- --------------------------------------------------------
+
+--
+-- Table structure for table `sprint_items`
+--
+
+CREATE TABLE `sprint_items` (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT,
+  `sprint_id` bigint UNSIGNED NOT NULL,
+  `title` varchar(255) NOT NULL,
+  `reference` varchar(1024) DEFAULT NULL,
+  `estimate` decimal(8,2) DEFAULT NULL,
+  `status` enum('todo', 'in_progress', 'done') NOT NULL DEFAULT 'todo',
+  `assignee_id` bigint UNSIGNED DEFAULT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  KEY (sprint_id),
+  FOREIGN KEY (sprint_id) REFERENCES sprints(id) ON DELETE CASCADE
+);
+
+-- --------------------------------------------------------
+
+--
+-- Table structure for table `sprint_carry_overs`
+--
+-- Kept after the sprints are deleted for reporting.
+--
+
+CREATE TABLE `sprint_carry_overs` (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT,
+  `from_sprint_id` bigint UNSIGNED NOT NULL,
+  `to_sprint_id` bigint UNSIGNED NOT NULL,
+  `from_item_id` bigint UNSIGNED NOT NULL,
+  `to_item_id` bigint UNSIGNED NOT NULL,
+  `mode` enum('move', 'copy') NOT NULL,
+  `estimate` decimal(8,2) DEFAULT NULL,
+  `user_id` bigint UNSIGNED NOT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  KEY (from_sprint_id),
+  KEY (to_sprint_id)
+);
+
+-- --------------------------------------------------------
+
+--
+-- Table structure for table `sprint_item_events`
+--
+-- Status changes of sprint items, replayed for burndown charts.
+-- `removed` is recorded when an item leaves the sprint.
+--
+
+CREATE TABLE `sprint_item_events` (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT,
+  `sprint_id` bigint UNSIGNED NOT NULL,
+  `item_id` bigint UNSIGNED NOT NULL,
+  `status` enum('todo', 'in_progress', 'done', 'removed') NOT NULL,
+  `estimate` decimal(8,2) DEFAULT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  KEY (sprint_id, created_at),
+  FOREIGN KEY (sprint_id) REFERENCES sprints(id) ON DELETE CASCADE
+);
+
+-- --------------------------------------------------------
+
+--
+-- Table structure for table `sprint_capacities`
+--
+
+CREATE TABLE `sprint_capacities` (
+  `sprint_id` bigint UNSIGNED NOT NULL,
+  `user_id` bigint UNSIGNED NOT NULL,
+  `allocation` tinyint UNSIGNED NOT NULL,
+  `days_off` decimal(5,1) NOT NULL DEFAULT 0,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (sprint_id, user_id),
+  FOREIGN KEY (sprint_id) REFERENCES sprints(id) ON DELETE CASCADE
+);
+
+-- --------------------------------------------------------
+
+--
+-- Table structure for table `sprint_retro_entries`
+--
+-- `user_id` of anonymous entries is only shown to their author.
+--
+
+CREATE TABLE `sprint_retro_entries` (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT,
+  `sprint_id` bigint UNSIGNED NOT NULL,
+  `user_id` bigint UNSIGNED NOT NULL,
+  `category` enum('went_well', 'to_improve', 'action_item') NOT NULL,
+  `text` varchar(1024) NOT NULL,
+  `anonymous` boolean NOT NULL DEFAULT false,
+  `carried_to_sprint_id` bigint UNSIGNED DEFAULT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  KEY (sprint_id),
+  FOREIGN KEY (sprint_id) REFERENCES sprints(id) ON DELETE CASCADE
+);
+
+-- --------------------------------------------------------
+
+--
+-- Table structure for table `sprint_retro_votes`
+--
+
+CREATE TABLE `sprint_retro_votes` (
+  `entry_id` bigint UNSIGNED NOT NULL,
+  `user_id` bigint UNSIGNED NOT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (entry_id, user_id),
+  FOREIGN KEY (entry_id) REFERENCES sprint_retro_entries(id) ON DELETE CASCADE
+);
+
+-- --------------------------------------------------------
+
+--
+-- Table structure for table `sprint_standups`
+--
+
+CREATE TABLE `sprint_standups` (
+  `sprint_id` bigint UNSIGNED NOT NULL,
+  `user_id` bigint UNSIGNED NOT NULL,
+  `date` date NOT NULL,
+  `text` varchar(1024) NOT NULL,
+  `blockers` varchar(1024) DEFAULT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (sprint_id, date, user_id),
+  FOREIGN KEY (sprint_id) REFERENCES sprints(id) ON DELETE CASCADE
+);
+
+-- --------------------------------------------------------
+
+--
+-- Table structure for table `user_settings`
+--
+
+CREATE TABLE `user_settings` (
+  `user_id` bigint UNSIGNED NOT NULL,
+  `time_zone` varchar(64) DEFAULT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (user_id)
+);
+
+-- --------------------------------------------------------
+
+--
+-- Table structure for table `project_settings`
+--
+
+CREATE TABLE `project_settings` (
+  `project_id` bigint UNSIGNED NOT NULL,
+  `time_zone` varchar(64) DEFAULT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (project_id)
+);
+
+-- --------------------------------------------------------
+
+--
+-- Table structure for table `sprint_templates`
+--
+
+CREATE TABLE `sprint_templates` (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT,
+  `user_id` bigint UNSIGNED NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `name_pattern` varchar(255) NOT NULL,
+  `length` smallint UNSIGNED NOT NULL,
+  `description` varchar(255) DEFAULT NULL,
+  `goals` text DEFAULT NULL,
+  `project_id` bigint UNSIGNED DEFAULT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  KEY (user_id)
+);
+
+-- Sprints of a deleted team become personal sprints of their creators
+ALTER TABLE `sprints`
+  ADD FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE SET NULL;
\ No newline at end of file
@@ -0,0 +1,17 @@
+--
+-- Personal access tokens
+--
+
+CREATE TABLE `personal_access_tokens` (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT,
+  `user_id` bigint UNSIGNED NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `token_hash` char(64) NOT NULL,
+  `scopes` varchar(1024) DEFAULT NULL,
+  `expires_at` DATETIME DEFAULT NULL,
+  `last_used_at` DATETIME DEFAULT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY (token_hash),
+  KEY (user_id)
+);
@@ -0,0 +1,14 @@
+--
+-- Sharing sprints with other users
+--
+
+CREATE TABLE `sprint_shares` (
+  `sprint_id` bigint UNSIGNED NOT NULL,
+  `user_id` bigint UNSIGNED NOT NULL,
+  `role` enum('viewer', 'editor') NOT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (sprint_id, user_id),
+  KEY (user_id),
+  FOREIGN KEY (sprint_id) REFERENCES sprints(id) ON DELETE CASCADE
+);
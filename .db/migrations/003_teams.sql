@@ -0,0 +1,30 @@
+--
+-- Team ownership of sprints
+--
+-- Existing sprints stay personal sprints (`team_id` is NULL) of `user_id`.
+-- Move them to a team with `POST /teams/:id/sprints` or `PATCH /:id` with `team_id`.
+--
+
+CREATE TABLE `teams` (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT,
+  `name` varchar(255) NOT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (id)
+);
+
+CREATE TABLE `team_members` (
+  `team_id` bigint UNSIGNED NOT NULL,
+  `user_id` bigint UNSIGNED NOT NULL,
+  `role` enum('owner', 'admin', 'member') NOT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (team_id, user_id),
+  KEY (user_id),
+  FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE CASCADE
+);
+
+ALTER TABLE `sprints`
+  ADD `team_id` bigint UNSIGNED DEFAULT NULL AFTER `project_id`,
+  ADD KEY (team_id),
+  ADD FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE SET NULL;
@@ -0,0 +1,18 @@
+--
+-- Sprint backlog items
+--
+
+CREATE TABLE `sprint_items` (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT,
+  `sprint_id` bigint UNSIGNED NOT NULL,
+  `title` varchar(255) NOT NULL,
+  `reference` varchar(1024) DEFAULT NULL,
+  `estimate` decimal(8,2) DEFAULT NULL,
+  `status` enum('todo', 'in_progress', 'done') NOT NULL DEFAULT 'todo',
+  `assignee_id` bigint UNSIGNED DEFAULT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  KEY (sprint_id),
+  FOREIGN KEY (sprint_id) REFERENCES sprints(id) ON DELETE CASCADE
+);
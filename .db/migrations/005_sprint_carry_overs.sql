@@ -0,0 +1,18 @@
+--
+-- Carry-over of unfinished sprint items
+--
+
+CREATE TABLE `sprint_carry_overs` (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT,
+  `from_sprint_id` bigint UNSIGNED NOT NULL,
+  `to_sprint_id` bigint UNSIGNED NOT NULL,
+  `from_item_id` bigint UNSIGNED NOT NULL,
+  `to_item_id` bigint UNSIGNED NOT NULL,
+  `mode` enum('move', 'copy') NOT NULL,
+  `estimate` decimal(8,2) DEFAULT NULL,
+  `user_id` bigint UNSIGNED NOT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  KEY (from_sprint_id),
+  KEY (to_sprint_id)
+);
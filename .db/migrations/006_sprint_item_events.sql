@@ -0,0 +1,20 @@
+--
+-- Status changes of sprint items for burndown charts
+--
+-- Existing items are recorded with their current status at the time they were created.
+--
+
+CREATE TABLE `sprint_item_events` (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT,
+  `sprint_id` bigint UNSIGNED NOT NULL,
+  `item_id` bigint UNSIGNED NOT NULL,
+  `status` enum('todo', 'in_progress', 'done', 'removed') NOT NULL,
+  `estimate` decimal(8,2) DEFAULT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  KEY (sprint_id, created_at),
+  FOREIGN KEY (sprint_id) REFERENCES sprints(id) ON DELETE CASCADE
+);
+
+INSERT INTO `sprint_item_events` (sprint_id, item_id, status, estimate, created_at)
+  SELECT sprint_id, id, status, estimate, created_at FROM `sprint_items`;
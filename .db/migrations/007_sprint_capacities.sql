@@ -0,0 +1,14 @@
+--
+-- Availability of members per sprint
+--
+
+CREATE TABLE `sprint_capacities` (
+  `sprint_id` bigint UNSIGNED NOT NULL,
+  `user_id` bigint UNSIGNED NOT NULL,
+  `allocation` tinyint UNSIGNED NOT NULL,
+  `days_off` decimal(5,1) NOT NULL DEFAULT 0,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (sprint_id, user_id),
+  FOREIGN KEY (sprint_id) REFERENCES sprints(id) ON DELETE CASCADE
+);
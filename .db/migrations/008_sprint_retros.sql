@@ -0,0 +1,29 @@
+--
+-- Sprint goals and retrospectives
+--
+
+ALTER TABLE `sprints`
+  ADD `goals` text DEFAULT NULL AFTER `description`;
+
+CREATE TABLE `sprint_retro_entries` (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT,
+  `sprint_id` bigint UNSIGNED NOT NULL,
+  `user_id` bigint UNSIGNED NOT NULL,
+  `category` enum('went_well', 'to_improve', 'action_item') NOT NULL,
+  `text` varchar(1024) NOT NULL,
+  `anonymous` boolean NOT NULL DEFAULT false,
+  `carried_to_sprint_id` bigint UNSIGNED DEFAULT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  KEY (sprint_id),
+  FOREIGN KEY (sprint_id) REFERENCES sprints(id) ON DELETE CASCADE
+);
+
+CREATE TABLE `sprint_retro_votes` (
+  `entry_id` bigint UNSIGNED NOT NULL,
+  `user_id` bigint UNSIGNED NOT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (entry_id, user_id),
+  FOREIGN KEY (entry_id) REFERENCES sprint_retro_entries(id) ON DELETE CASCADE
+);
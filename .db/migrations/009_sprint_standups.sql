@@ -0,0 +1,15 @@
+--
+-- Daily stand-up entries
+--
+
+CREATE TABLE `sprint_standups` (
+  `sprint_id` bigint UNSIGNED NOT NULL,
+  `user_id` bigint UNSIGNED NOT NULL,
+  `date` date NOT NULL,
+  `text` varchar(1024) NOT NULL,
+  `blockers` varchar(1024) DEFAULT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (sprint_id, date, user_id),
+  FOREIGN KEY (sprint_id) REFERENCES sprints(id) ON DELETE CASCADE
+);
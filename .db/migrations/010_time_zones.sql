@@ -0,0 +1,23 @@
+--
+-- Time zones of users and projects, and times of day of sprint boundaries
+--
+
+ALTER TABLE `sprints`
+  ADD `start_time` time DEFAULT NULL AFTER `end`,
+  ADD `end_time` time DEFAULT NULL AFTER `start_time`;
+
+CREATE TABLE `user_settings` (
+  `user_id` bigint UNSIGNED NOT NULL,
+  `time_zone` varchar(64) DEFAULT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (user_id)
+);
+
+CREATE TABLE `project_settings` (
+  `project_id` bigint UNSIGNED NOT NULL,
+  `time_zone` varchar(64) DEFAULT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (project_id)
+);
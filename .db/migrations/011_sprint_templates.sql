@@ -0,0 +1,18 @@
+--
+-- Sprint templates
+--
+
+CREATE TABLE `sprint_templates` (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT,
+  `user_id` bigint UNSIGNED NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `name_pattern` varchar(255) NOT NULL,
+  `length` smallint UNSIGNED NOT NULL,
+  `description` varchar(255) DEFAULT NULL,
+  `goals` text DEFAULT NULL,
+  `project_id` bigint UNSIGNED DEFAULT NULL,
+  `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  KEY (user_id)
+);
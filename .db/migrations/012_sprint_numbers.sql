@@ -0,0 +1,14 @@
+--
+-- Sequence numbers of sprints per project
+--
+
+ALTER TABLE `sprints`
+  ADD `number` int UNSIGNED DEFAULT NULL AFTER `project_id`;
+
+-- Number existing sprints in the order of creation
+UPDATE `sprints` s
+  JOIN (SELECT id, ROW_NUMBER() OVER (PARTITION BY project_id ORDER BY id) AS n FROM `sprints` WHERE project_id IS NOT NULL) r ON r.id = s.id
+  SET s.number = r.n;
+
+ALTER TABLE `sprints`
+  ADD UNIQUE KEY (project_id, number);
@@ -0,0 +1,92 @@
+package flags
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Values loaded from the config file, keyed by flag name (e.g. `mysql-host`).
+var config map[string]interface{}
+
+// Errors found while reading env variables, secret files and the config file.
+// Reported by Validate.
+var loadErrs []error
+
+// Find config file path from command line params or env variables.
+// It must be known before the other flags are defined because the config
+// file provides their defaults.
+func configPath() string {
+	args := os.Args[1:]
+	for i, a := range args {
+		for _, name := range []string{"-config", "--config"} {
+			if a == name && i+1 < len(args) {
+				return args[i+1]
+			}
+			if strings.HasPrefix(a, name+"=") {
+				return strings.TrimPrefix(a, name+"=")
+			}
+		}
+	}
+	return os.Getenv("CONFIG_FILE")
+}
+
+// Load YAML config file
+func loadConfig(path string) (map[string]interface{}, error) {
+	c := map[string]interface{}{}
+	if path == "" {
+		return c, nil
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	if err = yaml.UnmarshalStrict(b, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse config file `%s`: %w", path, err)
+	}
+
+	// `allow-origin` is the name of the command line param, accept it as `allow-origins`
+	if v, ok := c["allow-origin"]; ok {
+		if _, ok := c["allow-origins"]; ok {
+			return nil, fmt.Errorf("config file `%s` has both `allow-origin` and `allow-origins`", path)
+		}
+		c["allow-origins"] = v
+		delete(c, "allow-origin")
+	}
+	return c, nil
+}
+
+// Get string config value
+func getConfig(key string) (string, bool) {
+	v, ok := config[flagName(key)]
+	if !ok || v == nil {
+		return "", false
+	}
+	return fmt.Sprint(v), true
+}
+
+// Get list config value
+func getConfigList(key string) ([]string, bool) {
+	v, ok := config[flagName(key)]
+	if !ok || v == nil {
+		return nil, false
+	}
+	list, ok := v.([]interface{})
+	if !ok {
+		return []string{fmt.Sprint(v)}, true
+	}
+	var strs []string
+	for _, item := range list {
+		strs = append(strs, fmt.Sprint(item))
+	}
+	return strs, true
+}
+
+// Env variable name -> flag name
+// e.g. `MYSQL_HOST` -> `mysql-host`
+func flagName(key string) string {
+	return strings.ReplaceAll(strings.ToLower(key), "_", "-")
+}
@@ -2,6 +2,8 @@ package flags
 
 import (
 	"flag"
+	"fmt"
+	"sync"
 )
 
 type AllowOrigins []string
@@ -26,31 +28,71 @@ func (i *AllowOrigins) Set(v string) error {
 }
 
 type Flags struct {
-	Port               *uint
-	LogLevel           *uint
-	GzipLevel          *uint
-	AllowOrigins       AllowOrigins
-	MysqlHost          *string
-	MysqlPort          *uint
-	MysqlDB            *string
-	MysqlUser          *string
-	MysqlPasswd        *string
-	JwtIssuer          *string
-	JwtSecret          *string
-	ServiceUrlProjects *string
+	Port                   *uint
+	LogLevel               *uint
+	GzipLevel              *uint
+	AllowOrigins           AllowOrigins
+	MysqlHost              *string
+	MysqlPort              *uint
+	MysqlDB                *string
+	MysqlUser              *string
+	MysqlPasswd            *string
+	JwtIssuer              *string
+	JwtSecret              *string
+	JwtAlgorithm           *string
+	JwtPublicKeyFile       *string
+	JwtJwksUrl             *string
+	JwtJwksRefresh         *uint
+	JwtAudience            *string
+	JwtRequireScopes       *bool
+	ServiceUrlProjects     *string
+	ShutdownTimeout        *uint
+	ReadinessTimeout       *uint
+	ReadinessCheckProjects *bool
+	TraceExporter          *string
+	TraceEndpoint          *string
+	TraceInsecure          *bool
+	LogFormat              *string
+	AccessLog              *bool
+	RateLimitRead          *uint
+	RateLimitWrite         *uint
+	BodyLimit              *string
+	TimeZone               *string
+	ShutdownDelay          *uint
+	RateLimitIp            *uint
+	TrustedProxies         *string
 }
 
-var flags Flags
+var (
+	flags   Flags
+	flagsMu sync.RWMutex
+)
 
 func Get() Flags {
+	flagsMu.RLock()
+	if flags.Port != nil {
+		defer flagsMu.RUnlock()
+		return flags
+	}
+	flagsMu.RUnlock()
+
+	flagsMu.Lock()
+	defer flagsMu.Unlock()
 	if flags.Port == nil {
 		return parse()
 	}
 	return flags
 }
 
-// Priority: command line params > env variables > default value
+// Priority: command line params > env variables > config file > default value
 func parse() Flags {
+	// Load config file
+	var err error
+	config, err = loadConfig(configPath())
+	if err != nil {
+		loadErrs = append(loadErrs, err)
+	}
+
 	flags = Flags{
 		flag.Uint("port", getUintEnv("PORT", 1323), "Server port"),
 		flag.Uint("log-level", getUintEnv("LOG_LEVEL", 2), "Log level (1: 'DEBUG', 2: 'INFO', 3: 'WARN', 4: 'ERROR', 5: 'OFF', 6: 'PANIC', 7: 'FATAL'"),
@@ -63,10 +105,94 @@ func parse() Flags {
 		flag.String("mysql-password", getEnv("MYSQL_PASSWORD", ""), "MySQL password"),
 		flag.String("jwt-issuer", getEnv("JWT_ISSUER", "flow-users"), "JWT issuer"),
 		flag.String("jwt-secret", getEnv("JWT_SECRET", ""), "JWT secret"),
+		flag.String("jwt-algorithm", getEnv("JWT_ALGORITHM", "HS256"), "JWT signing algorithm ('HS256', 'RS256', 'ES256')"),
+		flag.String("jwt-public-key-file", getEnv("JWT_PUBLIC_KEY_FILE", ""), "PEM file of JWT public key (RS256/ES256)"),
+		flag.String("jwt-jwks-url", getEnv("JWT_JWKS_URL", ""), "JWKS url of JWT public keys (RS256/ES256)"),
+		flag.Uint("jwt-jwks-refresh", getUintEnv("JWT_JWKS_REFRESH", 3600), "Seconds between JWKS refreshes"),
+		flag.String("jwt-audience", getEnv("JWT_AUDIENCE", ""), "JWT audience (`aud`) to accept (empty: not checked)"),
+		flag.Bool("jwt-require-scopes", getBoolEnv("JWT_REQUIRE_SCOPES", false), "Reject tokens without `scope`/`scopes` claim instead of granting all scopes"),
 		flag.String("service-url-projects", getEnv("SERVICE_URL_PROJECTS", ""), "Service url: flow-projects"),
+		flag.Uint("shutdown-timeout", getUintEnv("SHUTDOWN_TIMEOUT", 10), "Seconds to wait for in-flight requests on shutdown"),
+		flag.Uint("readiness-timeout", getUintEnv("READINESS_TIMEOUT", 2), "Seconds to wait for each dependency in readiness check"),
+		flag.Bool("readiness-check-projects", getBoolEnv("READINESS_CHECK_PROJECTS", false), "Check health of flow-projects in readiness check"),
+		flag.String("trace-exporter", getEnv("TRACE_EXPORTER", "none"), "OpenTelemetry trace exporter ('none', 'stdout', 'otlp')"),
+		flag.String("trace-endpoint", getEnv("TRACE_ENDPOINT", ""), "OTLP/HTTP endpoint (host:port) for trace exporter 'otlp'"),
+		flag.Bool("trace-insecure", getBoolEnv("TRACE_INSECURE", false), "Disable TLS for trace exporter 'otlp'"),
+		flag.String("log-format", getEnv("LOG_FORMAT", "json"), "Log format ('json', 'logfmt')"),
+		flag.Bool("access-log", getBoolEnv("ACCESS_LOG", true), "Enable access logging"),
+		flag.Uint("rate-limit-read", getUintEnv("RATE_LIMIT_READ", 600), "Read requests per minute per user (0: unlimited)"),
+		flag.Uint("rate-limit-write", getUintEnv("RATE_LIMIT_WRITE", 60), "Write requests per minute per user (0: unlimited)"),
+		flag.String("body-limit", getEnv("BODY_LIMIT", "1M"), "Maximum request body size (e.g. '512K', '1M')"),
+		flag.String("time-zone", getEnv("TIME_ZONE", "UTC"), "Default time zone of dates such as today (IANA name, e.g. 'Asia/Tokyo')"),
+		flag.Uint("shutdown-delay", getUintEnv("SHUTDOWN_DELAY", 5), "Seconds to report unready before closing listeners on shutdown"),
+		flag.Uint("rate-limit-ip", getUintEnv("RATE_LIMIT_IP", 1200), "Requests per minute per IP before authentication (0: unlimited)"),
+		flag.String("trusted-proxies", getEnv("TRUSTED_PROXIES", ""), "Comma-separated CIDRs of proxies whose X-Forwarded-For is trusted for rate limits (e.g. '10.0.0.0/8')"),
 	}
+	flag.String("config", configPath(), "Path to YAML config file (keys are flag names)")
 	flag.Var(&flags.AllowOrigins, "allow-origin", "CORS allow origins")
 
 	flag.Parse()
+
+	if !isSet("allow-origin") {
+		flags.AllowOrigins = getListEnv("ALLOW_ORIGINS", AllowOrigins{})
+	}
+
+	// Check unknown keys in config file
+	for k := range config {
+		if flag.Lookup(k) == nil && k != "allow-origins" {
+			loadErrs = append(loadErrs, fmt.Errorf("unknown key `%s` in config file", k))
+		}
+	}
 	return flags
 }
+
+// Reload re-reads the config file and applies the settings that are safe to
+// change at runtime (log level and CORS allow origins).
+// Command line params and env variables keep priority over the config file.
+func Reload() (Flags, error) {
+	flagsMu.Lock()
+	defer flagsMu.Unlock()
+
+	c, err := loadConfig(configPath())
+	if err != nil {
+		return flags, err
+	}
+
+	// Evaluate with new config
+	prevConfig, prevErrs := config, loadErrs
+	config, loadErrs = c, nil
+	defer func() { config, loadErrs = prevConfig, prevErrs }()
+
+	logLevel := *flags.LogLevel
+	if !isSet("log-level") {
+		logLevel = getUintEnv("LOG_LEVEL", 2)
+	}
+	allowOrigins := flags.AllowOrigins
+	if !isSet("allow-origin") {
+		allowOrigins = getListEnv("ALLOW_ORIGINS", AllowOrigins{})
+	}
+	if len(loadErrs) != 0 {
+		return flags, loadErrs[0]
+	}
+	if err = validateLogLevel(logLevel); err != nil {
+		return flags, err
+	}
+
+	// Publish a new value instead of writing through the shared pointers,
+	// which are read without the lock
+	nf := flags
+	nf.LogLevel = &logLevel
+	nf.AllowOrigins = allowOrigins
+	flags = nf
+	return flags, nil
+}
+
+// Check if the flag was given as a command line param
+func isSet(name string) (set bool) {
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return
+}
@@ -1,30 +1,83 @@
 package flags
 
 import (
+	"fmt"
+	"io/ioutil"
 	"os"
 	"strconv"
+	"strings"
 )
 
-// Get uint env variable
-func getUintEnv(key string, fallback uint) uint {
+// Lookup a value
+// Priority: env variables > `<key>_FILE` env variables > config file
+func lookup(key string) (string, bool) {
 	// Get env
 	if value, ok := os.LookupEnv(key); ok {
+		return value, true
+	}
+	// Get secret from file
+	if path, ok := os.LookupEnv(key + "_FILE"); ok {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			loadErrs = append(loadErrs, fmt.Errorf("failed to read `%s_FILE`: %w", key, err))
+			return "", false
+		}
+		return strings.TrimRight(string(b), "\r\n"), true
+	}
+	// Get config
+	return getConfig(key)
+}
+
+// Get uint env variable
+func getUintEnv(key string, fallback uint) uint {
+	if value, ok := lookup(key); ok {
 		// parse to uint
-		var intValue, err = strconv.ParseUint(value, 10, 16)
+		var intValue, err = strconv.ParseUint(value, 10, strconv.IntSize)
 		if err == nil {
 			return uint(intValue)
 		}
+		loadErrs = append(loadErrs, fmt.Errorf("invalid value `%s` for `%s`: must be an unsigned integer", value, key))
+	}
+	// Use fallback when `key` does not exist or failed to parse
+	return fallback
+}
+
+// Get bool env variable
+func getBoolEnv(key string, fallback bool) bool {
+	if value, ok := lookup(key); ok {
+		// parse to bool
+		var boolValue, err = strconv.ParseBool(value)
+		if err == nil {
+			return boolValue
+		}
+		loadErrs = append(loadErrs, fmt.Errorf("invalid value `%s` for `%s`: must be a boolean", value, key))
 	}
-	// Use fallbacn when env using `key` does not exist or failed to parse
+	// Use fallback when `key` does not exist or failed to parse
 	return fallback
 }
 
 // Get string env variable
 func getEnv(key, fallback string) string {
-	// Get env
-	if value, ok := os.LookupEnv(key); ok {
+	if value, ok := lookup(key); ok {
 		return value
 	}
-	// Use fallbacn when env using `key` does not exist
+	// Use fallback when `key` does not exist
+	return fallback
+}
+
+// Get comma separated list env variable
+func getListEnv(key string, fallback []string) []string {
+	if value, ok := os.LookupEnv(key); ok {
+		var list []string
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				list = append(list, v)
+			}
+		}
+		return list
+	}
+	if list, ok := getConfigList(key); ok {
+		return list
+	}
 	return fallback
 }
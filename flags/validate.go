@@ -0,0 +1,85 @@
+package flags
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Format accepted by echo's BodyLimit middleware
+var bodyLimitPattern = regexp.MustCompile(`^\d+(\.\d+)?[KMGTP]?B?$`)
+
+// Validate checks the values of all flags.
+// It reports every problem at once so that misconfiguration is fixed in one go.
+func Validate(f Flags) error {
+	errs := append([]error{}, loadErrs...)
+
+	if *f.Port == 0 || *f.Port > 65535 {
+		errs = append(errs, fmt.Errorf("`port` must be between 1 and 65535, got %d", *f.Port))
+	}
+	if err := validateLogLevel(*f.LogLevel); err != nil {
+		errs = append(errs, err)
+	}
+	if *f.GzipLevel > 9 {
+		errs = append(errs, fmt.Errorf("`gzip-level` must be between 0 and 9, got %d", *f.GzipLevel))
+	}
+	if *f.MysqlPort == 0 || *f.MysqlPort > 65535 {
+		errs = append(errs, fmt.Errorf("`mysql-port` must be between 1 and 65535, got %d", *f.MysqlPort))
+	}
+	switch *f.JwtAlgorithm {
+	case "HS256":
+		if *f.JwtSecret == "" {
+			errs = append(errs, errors.New("`jwt-secret` (`JWT_SECRET` or `JWT_SECRET_FILE`) is required"))
+		}
+	case "RS256", "ES256":
+		if (*f.JwtPublicKeyFile == "") == (*f.JwtJwksUrl == "") {
+			errs = append(errs, fmt.Errorf("either `jwt-public-key-file` or `jwt-jwks-url` is required for `jwt-algorithm` '%s'", *f.JwtAlgorithm))
+		}
+		if *f.JwtJwksRefresh == 0 {
+			errs = append(errs, errors.New("`jwt-jwks-refresh` must be greater than 0"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("`jwt-algorithm` must be 'HS256', 'RS256' or 'ES256', got '%s'", *f.JwtAlgorithm))
+	}
+	if *f.LogFormat != "json" && *f.LogFormat != "logfmt" {
+		errs = append(errs, fmt.Errorf("`log-format` must be 'json' or 'logfmt', got '%s'", *f.LogFormat))
+	}
+	if !bodyLimitPattern.MatchString(*f.BodyLimit) {
+		errs = append(errs, fmt.Errorf("`body-limit` must be a size such as '512K' or '1M', got '%s'", *f.BodyLimit))
+	}
+	if _, err := time.LoadLocation(*f.TimeZone); err != nil {
+		errs = append(errs, fmt.Errorf("`time-zone` must be an IANA time zone name such as 'Asia/Tokyo', got '%s'", *f.TimeZone))
+	}
+	for _, cidr := range strings.Split(*f.TrustedProxies, ",") {
+		if cidr = strings.TrimSpace(cidr); cidr == "" {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			errs = append(errs, fmt.Errorf("`trusted-proxies` must be comma-separated CIDRs such as '10.0.0.0/8', got '%s'", cidr))
+		}
+	}
+	switch *f.TraceExporter {
+	case "none", "stdout", "otlp":
+	default:
+		errs = append(errs, fmt.Errorf("`trace-exporter` must be 'none', 'stdout' or 'otlp', got '%s'", *f.TraceExporter))
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = "  - " + err.Error()
+	}
+	return fmt.Errorf("invalid configuration:\n%s", strings.Join(msgs, "\n"))
+}
+
+func validateLogLevel(l uint) error {
+	if l < 1 || l > 7 {
+		return fmt.Errorf("`log-level` must be between 1 and 7, got %d", l)
+	}
+	return nil
+}
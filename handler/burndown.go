@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"flow-sprints/flags"
+	"flow-sprints/jwt"
+	"flow-sprints/logging"
+	"flow-sprints/sprint"
+	"net/http"
+	"strconv"
+
+	jwtGo "github.com/dgrijalva/jwt-go"
+	"github.com/labstack/echo"
+)
+
+func GetBurndown(c echo.Context) error {
+	// Check token
+	u := c.Get("user").(*jwtGo.Token)
+	userId, err := jwt.CheckToken(*flags.Get().JwtIssuer, *flags.Get().JwtAudience, u)
+	if err != nil {
+		logging.Ctx(c).Debug(err)
+		return c.JSONPretty(http.StatusUnauthorized, map[string]string{"message": err.Error()}, "	")
+	}
+
+	// id
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		// 404: Not found
+		return echo.ErrNotFound
+	}
+
+	// format
+	format := c.QueryParam("format")
+	if format != "" && format != "json" && format != "svg" {
+		// 422: Unprocessable entity
+		logging.Ctx(c).Debugf("invalid format: %s", format)
+		return c.JSONPretty(http.StatusUnprocessableEntity, map[string]string{"message": "`format` must be json or svg"}, "	")
+	}
+
+	b, notFound, err := sprint.GetBurndown(c.Request().Context(), userId, id)
+	if err != nil {
+		// 500: Internal server error
+		logging.Ctx(c).Error(err)
+		return c.JSONPretty(http.StatusInternalServerError, map[string]string{"message": err.Error()}, "	")
+	}
+	if notFound {
+		// 404: Not found
+		logging.Ctx(c).Debug("sprint not found")
+		return echo.ErrNotFound
+	}
+
+	// 200: Success
+	if format == "svg" {
+		return c.Blob(http.StatusOK, "image/svg+xml", b.SVG())
+	}
+	return c.JSONPretty(http.StatusOK, b, "	")
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"flow-sprints/flags"
+	"flow-sprints/jwt"
+	"flow-sprints/logging"
+	"flow-sprints/sprint"
+	"net/http"
+	"strconv"
+
+	jwtGo "github.com/dgrijalva/jwt-go"
+	"github.com/labstack/echo"
+)
+
+func DeleteCapacity(c echo.Context) error {
+	// Check token
+	u := c.Get("user").(*jwtGo.Token)
+	userId, err := jwt.CheckToken(*flags.Get().JwtIssuer, *flags.Get().JwtAudience, u)
+	if err != nil {
+		logging.Ctx(c).Debug(err)
+		return c.JSONPretty(http.StatusUnauthorized, map[string]string{"message": err.Error()}, "	")
+	}
+
+	// id, user_id
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		// 404: Not found
+		return echo.ErrNotFound
+	}
+	memberId, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
+	if err != nil {
+		// 404: Not found
+		return echo.ErrNotFound
+	}
+
+	notFound, forbidden, err := sprint.DeleteCapacity(c.Request().Context(), userId, id, memberId)
+	if err != nil {
+		// 500: Internal server error
+		logging.Ctx(c).Error(err)
+		return c.JSONPretty(http.StatusInternalServerError, map[string]string{"message": err.Error()}, "	")
+	}
+	if notFound {
+		// 404: Not found
+		logging.Ctx(c).Debug("capacity not found")
+		return echo.ErrNotFound
+	}
+	if forbidden {
+		// 403: Forbidden
+		logging.Ctx(c).Debug("no permission to update the sprint")
+		return c.JSONPretty(http.StatusForbidden, map[string]string{"message": "no permission to update the sprint"}, "	")
+	}
+
+	// 204: No content
+	return c.JSONPretty(http.StatusNoContent, map[string]string{"message": "Deleted"}, "	")
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"flow-sprints/flags"
+	"flow-sprints/jwt"
+	"flow-sprints/logging"
+	"flow-sprints/sprint"
+	"net/http"
+	"strconv"
+
+	jwtGo "github.com/dgrijalva/jwt-go"
+	"github.com/labstack/echo"
+)
+
+func GetCapacity(c echo.Context) error {
+	// Check token
+	u := c.Get("user").(*jwtGo.Token)
+	userId, err := jwt.CheckToken(*flags.Get().JwtIssuer, *flags.Get().JwtAudience, u)
+	if err != nil {
+		logging.Ctx(c).Debug(err)
+		return c.JSONPretty(http.StatusUnauthorized, map[string]string{"message": err.Error()}, "	")
+	}
+
+	// id
+	idStr := c.Param("id")
+
+	// string -> uint64
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		// 404: Not found
+		return echo.ErrNotFound
+	}
+
+	capacity, notFound, err := sprint.GetCapacity(c.Request().Context(), userId, id)
+	if err != nil {
+		// 500: Internal server error
+		logging.Ctx(c).Error(err)
+		return c.JSONPretty(http.StatusInternalServerError, map[string]string{"message": err.Error()}, "	")
+	}
+	if notFound {
+		// 404: Not found
+		logging.Ctx(c).Debug("sprint not found")
+		return echo.ErrNotFound
+	}
+
+	// 200: Success
+	return c.JSONPretty(http.StatusOK, capacity, "	")
+}
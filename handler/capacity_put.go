@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"flow-sprints/flags"
+	"flow-sprints/jwt"
+	"flow-sprints/logging"
+	"flow-sprints/sprint"
+	"net/http"
+	"strconv"
+	"strings"
+
+	jwtGo "github.com/dgrijalva/jwt-go"
+	"github.com/labstack/echo"
+)
+
+func PutCapacity(c echo.Context) error {
+	// Check `Content-Type`
+	if !strings.Contains(c.Request().Header.Get("Content-Type"), "application/json") {
+		// 415: Invalid `Content-Type`
+		return c.JSONPretty(http.StatusUnsupportedMediaType, map[string]string{"message": "unsupported media type"}, "	")
+	}
+
+	// Check token
+	u := c.Get("user").(*jwtGo.Token)
+	userId, err := jwt.CheckToken(*flags.Get().JwtIssuer, *flags.Get().JwtAudience, u)
+	if err != nil {
+		logging.Ctx(c).Debug(err)
+		return c.JSONPretty(http.StatusUnauthorized, map[string]string{"message": err.Error()}, "	")
+	}
+
+	// id, user_id
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		// 404: Not found
+		return echo.ErrNotFound
+	}
+	memberId, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
+	if err != nil {
+		// 404: Not found
+		return echo.ErrNotFound
+	}
+
+	// Bind request body
+	put := new(sprint.CapacityPutBody)
+	if err = c.Bind(put); err != nil {
+		// 400: Bad request
+		logging.Ctx(c).Debug(err)
+		return c.JSONPretty(http.StatusBadRequest, map[string]string{"message": err.Error()}, "	")
+	}
+
+	// Validate request body
+	if err = c.Validate(put); err != nil {
+		// 422: Unprocessable entity
+		logging.Ctx(c).Debug(err)
+		return c.JSONPretty(http.StatusUnprocessableEntity, map[string]string{"message": err.Error()}, "	")
+	}
+
+	m, notFound, forbidden, err := sprint.PutCapacity(c.Request().Context(), userId, id, memberId, *put)
+	if err != nil {
+		// 500: Internal server error
+		logging.Ctx(c).Error(err)
+		return c.JSONPretty(http.StatusInternalServerError, map[string]string{"message": err.Error()}, "	")
+	}
+	if notFound {
+		// 404: Not found
+		logging.Ctx(c).Debug("sprint not found")
+		return echo.ErrNotFound
+	}
+	if forbidden {
+		// 403: Forbidden
+		logging.Ctx(c).Debug("no permission to update the sprint")
+		return c.JSONPretty(http.StatusForbidden, map[string]string{"message": "no permission to update the sprint"}, "	")
+	}
+
+	// 200: Success
+	return c.JSONPretty(http.StatusOK, m, "	")
+}
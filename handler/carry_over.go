@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"flow-sprints/flags"
+	"flow-sprints/jwt"
+	"flow-sprints/logging"
+	"flow-sprints/sprint"
+	"net/http"
+	"strconv"
+	"strings"
+
+	jwtGo "github.com/dgrijalva/jwt-go"
+	"github.com/labstack/echo"
+)
+
+func CarryOver(c echo.Context) error {
+	// Check `Content-Type`
+	if !strings.Contains(c.Request().Header.Get("Content-Type"), "application/json") {
+		// 415: Invalid `Content-Type`
+		return c.JSONPretty(http.StatusUnsupportedMediaType, map[string]string{"message": "unsupported media type"}, "	")
+	}
+
+	// Check token
+	u := c.Get("user").(*jwtGo.Token)
+	userId, err := jwt.CheckToken(*flags.Get().JwtIssuer, *flags.Get().JwtAudience, u)
+	if err != nil {
+		logging.Ctx(c).Debug(err)
+		return c.JSONPretty(http.StatusUnauthorized, map[string]string{"message": err.Error()}, "	")
+	}
+
+	// id
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		// 404: Not found
+		return echo.ErrNotFound
+	}
+
+	// Bind request body
+	body := new(sprint.CarryOverBody)
+	if err = c.Bind(body); err != nil {
+		// 400: Bad request
+		logging.Ctx(c).Debug(err)
+		return c.JSONPretty(http.StatusBadRequest, map[string]string{"message": err.Error()}, "	")
+	}
+
+	// Validate request body
+	if err = c.Validate(body); err != nil {
+		// 422: Unprocessable entity
+		logging.Ctx(c).Debug(err)
+		return c.JSONPretty(http.StatusUnprocessableEntity, map[string]string{"message": err.Error()}, "	")
+	}
+
+	r, notFound, forbidden, noTarget, sameTarget, notEnded, err := sprint.CarryOver(c.Request().Context(), userId, id, *body)
+	if err != nil {
+		// 500: Internal server error
+		logging.Ctx(c).Error(err)
+		return c.JSONPretty(http.StatusInternalServerError, map[string]string{"message": err.Error()}, "	")
+	}
+	if notFound {
+		// 404: Not found
+		logging.Ctx(c).Debug("sprint not found")
+		return echo.ErrNotFound
+	}
+	if forbidden {
+		// 403: Forbidden
+		logging.Ctx(c).Debug("no permission to update the sprints")
+		return c.JSONPretty(http.StatusForbidden, map[string]string{"message": "no permission to update the sprints"}, "	")
+	}
+	if notEnded {
+		// 400: Bad request
+		logging.Ctx(c).Debug("sprint has not ended yet")
+		return c.JSONPretty(http.StatusBadRequest, map[string]string{"message": "sprint has not ended yet, set `force` to carry over anyway"}, "	")
+	}
+	if noTarget {
+		// 400: Bad request
+		logging.Ctx(c).Debug("target sprint not found")
+		return c.JSONPretty(http.StatusBadRequest, map[string]string{"message": "target sprint not found"}, "	")
+	}
+	if sameTarget {
+		// 400: Bad request
+		logging.Ctx(c).Debug("cannot carry over to the same sprint")
+		return c.JSONPretty(http.StatusBadRequest, map[string]string{"message": "cannot carry over to the same sprint"}, "	")
+	}
+
+	// 200: Success
+	return c.JSONPretty(http.StatusOK, r, "	")
+}
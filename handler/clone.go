@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"flow-sprints/flags"
+	"flow-sprints/jwt"
+	"flow-sprints/logging"
+	"flow-sprints/sprint"
+	"flow-sprints/utils"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	jwtGo "github.com/dgrijalva/jwt-go"
+	"github.com/labstack/echo"
+)
+
+func Clone(c echo.Context) error {
+	// Check `Content-Type`
+	if !strings.Contains(c.Request().Header.Get("Content-Type"), "application/json") {
+		// 415: Invalid `Content-Type`
+		return c.JSONPretty(http.StatusUnsupportedMediaType, map[string]string{"message": "unsupported media type"}, "	")
+	}
+
+	// Check token
+	u := c.Get("user").(*jwtGo.Token)
+	userId, err := jwt.CheckToken(*flags.Get().JwtIssuer, *flags.Get().JwtAudience, u)
+	if err != nil {
+		logging.Ctx(c).Debug(err)
+		return c.JSONPretty(http.StatusUnauthorized, map[string]string{"message": err.Error()}, "	")
+	}
+
+	// id
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		// 404: Not found
+		return echo.ErrNotFound
+	}
+
+	// Bind request body
+	body := new(sprint.CloneBody)
+	if err = c.Bind(body); err != nil {
+		// 400: Bad request
+		logging.Ctx(c).Debug(err)
+		return c.JSONPretty(http.StatusBadRequest, map[string]string{"message": err.Error()}, "	")
+	}
+
+	// Validate request body
+	if err = c.Validate(body); err != nil {
+		// 422: Unprocessable entity
+		logging.Ctx(c).Debug(err)
+		return c.JSONPretty(http.StatusUnprocessableEntity, map[string]string{"message": err.Error()}, "	")
+	}
+
+	// Check project id
+	if body.ProjectId != nil {
+		// The token is forwarded to flow-projects, which only accepts JWTs
+		if u.Claims.(*jwt.JwtCustumClaims).PersonalAccessTokenId != nil {
+			// 403: Forbidden
+			logging.Ctx(c).Debug("projects cannot be checked with a personal access token")
+			return c.JSONPretty(http.StatusForbidden, map[string]string{"message": "projects cannot be checked with a personal access token"}, "	")
+		}
+		status, err := utils.HttpGetContext(c.Request().Context(), fmt.Sprintf("%s/%d", *flags.Get().ServiceUrlProjects, *body.ProjectId), &u.Raw)
+		if err != nil {
+			// 500: Internal server error
+			logging.Ctx(c).Error(err)
+			return c.JSONPretty(http.StatusInternalServerError, map[string]string{"message": err.Error()}, "	")
+		}
+		if status != http.StatusOK {
+			// 400: Bad request
+			logging.Ctx(c).Debugf("project id: %d does not exist", *body.ProjectId)
+			return c.JSONPretty(http.StatusBadRequest, map[string]string{"message": fmt.Sprintf("project id: %d does not exist", *body.ProjectId)}, "	")
+		}
+	}
+
+	p, notFound, err := sprint.Clone(c.Request().Context(), userId, id, *body)
+	if err != nil {
+		// 500: Internal server error
+		logging.Ctx(c).Error(err)
+		return c.JSONPretty(http.StatusInternalServerError, map[string]string{"message": err.Error()}, "	")
+	}
+	if notFound {
+		// 404: Not found
+		logging.Ctx(c).Debug("sprint not found")
+		return echo.ErrNotFound
+	}
+
+	// 200: Success
+	return c.JSONPretty(http.StatusOK, p, "	")
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"flow-sprints/flags"
 	"flow-sprints/jwt"
+	"flow-sprints/logging"
 	"flow-sprints/sprint"
 	"net/http"
 	"strconv"
@@ -14,9 +15,9 @@ import (
 func Delete(c echo.Context) error {
 	// Check token
 	u := c.Get("user").(*jwtGo.Token)
-	userId, err := jwt.CheckToken(*flags.Get().JwtIssuer, u)
+	userId, err := jwt.CheckToken(*flags.Get().JwtIssuer, *flags.Get().JwtAudience, u)
 	if err != nil {
-		c.Logger().Debug(err)
+		logging.Ctx(c).Debug(err)
 		return c.JSONPretty(http.StatusUnauthorized, map[string]string{"message": err.Error()}, "	")
 	}
 
@@ -30,17 +31,22 @@ func Delete(c echo.Context) error {
 		return echo.ErrNotFound
 	}
 
-	notFound, err := sprint.Delete(userId, id)
+	notFound, forbidden, err := sprint.Delete(c.Request().Context(), userId, id)
 	if err != nil {
 		// 500: Internal server error
-		c.Logger().Error(err)
+		logging.Ctx(c).Error(err)
 		return c.JSONPretty(http.StatusInternalServerError, map[string]string{"message": err.Error()}, "	")
 	}
 	if notFound {
 		// 404: Not found
-		c.Logger().Debug("sprint not found")
+		logging.Ctx(c).Debug("sprint not found")
 		return echo.ErrNotFound
 	}
+	if forbidden {
+		// 403: Forbidden
+		logging.Ctx(c).Debug("only the owner can delete the sprint")
+		return c.JSONPretty(http.StatusForbidden, map[string]string{"message": "only the owner can delete the sprint"}, "	")
+	}
 
 	// 204: No content
 	return c.JSONPretty(http.StatusNoContent, map[string]string{"message": "Deleted"}, "	")
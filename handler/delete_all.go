@@ -3,6 +3,7 @@ package handler
 import (
 	"flow-sprints/flags"
 	"flow-sprints/jwt"
+	"flow-sprints/logging"
 	"flow-sprints/sprint"
 	"net/http"
 
@@ -13,16 +14,16 @@ import (
 func DeleteAll(c echo.Context) error {
 	// Check token
 	u := c.Get("user").(*jwtGo.Token)
-	userId, err := jwt.CheckToken(*flags.Get().JwtIssuer, u)
+	userId, err := jwt.CheckToken(*flags.Get().JwtIssuer, *flags.Get().JwtAudience, u)
 	if err != nil {
-		c.Logger().Debug(err)
+		logging.Ctx(c).Debug(err)
 		return c.JSONPretty(http.StatusUnauthorized, map[string]string{"message": err.Error()}, "	")
 	}
 
-	err = sprint.DeleteAll(userId)
+	err = sprint.DeleteAll(c.Request().Context(), userId)
 	if err != nil {
 		// 500: Internal server error
-		c.Logger().Error(err)
+		logging.Ctx(c).Error(err)
 		return c.JSONPretty(http.StatusInternalServerError, map[string]string{"message": err.Error()}, "	")
 	}
 
@@ -3,6 +3,7 @@ package handler
 import (
 	"flow-sprints/flags"
 	"flow-sprints/jwt"
+	"flow-sprints/logging"
 	"flow-sprints/sprint"
 	"net/http"
 	"strconv"
@@ -14,9 +15,9 @@ import (
 func Get(c echo.Context) error {
 	// Check token
 	u := c.Get("user").(*jwtGo.Token)
-	userId, err := jwt.CheckToken(*flags.Get().JwtIssuer, u)
+	userId, err := jwt.CheckToken(*flags.Get().JwtIssuer, *flags.Get().JwtAudience, u)
 	if err != nil {
-		c.Logger().Debug(err)
+		logging.Ctx(c).Debug(err)
 		return c.JSONPretty(http.StatusUnauthorized, map[string]string{"message": err.Error()}, "	")
 	}
 
@@ -30,15 +31,15 @@ func Get(c echo.Context) error {
 		return echo.ErrNotFound
 	}
 
-	s, notFound, err := sprint.Get(userId, id)
+	s, notFound, err := sprint.Get(c.Request().Context(), userId, id)
 	if err != nil {
 		// 500: Internal server error
-		c.Logger().Error(err)
+		logging.Ctx(c).Error(err)
 		return c.JSONPretty(http.StatusInternalServerError, map[string]string{"message": err.Error()}, "	")
 	}
 	if notFound {
 		// 404: Not found
-		c.Logger().Debug("project not found")
+		logging.Ctx(c).Debug("project not found")
 		return echo.ErrNotFound
 	}
 
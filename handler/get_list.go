@@ -3,6 +3,7 @@ package handler
 import (
 	"flow-sprints/flags"
 	"flow-sprints/jwt"
+	"flow-sprints/logging"
 	"flow-sprints/sprint"
 	"net/http"
 
@@ -13,9 +14,9 @@ import (
 func GetList(c echo.Context) error {
 	// Check token
 	u := c.Get("user").(*jwtGo.Token)
-	userId, err := jwt.CheckToken(*flags.Get().JwtIssuer, u)
+	userId, err := jwt.CheckToken(*flags.Get().JwtIssuer, *flags.Get().JwtAudience, u)
 	if err != nil {
-		c.Logger().Debug(err)
+		logging.Ctx(c).Debug(err)
 		return c.JSONPretty(http.StatusUnauthorized, map[string]string{"message": err.Error()}, "	")
 	}
 
@@ -23,22 +24,22 @@ func GetList(c echo.Context) error {
 	q := new(sprint.GetListQuery)
 	if err = c.Bind(q); err != nil {
 		// 400: Bad request
-		c.Logger().Debug(err)
+		logging.Ctx(c).Debug(err)
 		return c.JSONPretty(http.StatusBadRequest, map[string]string{"message": err.Error()}, "	")
 	}
 
 	// Validate query
 	if err = c.Validate(q); err != nil {
 		// 400: Bad request
-		c.Logger().Debug(err)
+		logging.Ctx(c).Debug(err)
 		return c.JSONPretty(http.StatusBadRequest, map[string]string{"message": err.Error()}, "	")
 	}
 
 	// Get sprints
-	sprints, err := sprint.GetList(userId, *q)
+	sprints, err := sprint.GetList(c.Request().Context(), userId, *q)
 	if err != nil {
 		// 500: Internal server error
-		c.Logger().Error(err)
+		logging.Ctx(c).Error(err)
 		return c.JSONPretty(http.StatusInternalServerError, map[string]string{"message": err.Error()}, "	")
 	}
 
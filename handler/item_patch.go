@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"flow-sprints/flags"
+	"flow-sprints/jwt"
+	"flow-sprints/logging"
+	"flow-sprints/sprint"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	jwtGo "github.com/dgrijalva/jwt-go"
+	"github.com/labstack/echo"
+)
+
+func PatchItem(c echo.Context) error {
+	// Check `Content-Type`
+	if !strings.Contains(c.Request().Header.Get("Content-Type"), "application/json") {
+		// 415: Invalid `Content-Type`
+		return c.JSONPretty(http.StatusUnsupportedMediaType, map[string]string{"message": "unsupported media type"}, "	")
+	}
+
+	// Check token
+	u := c.Get("user").(*jwtGo.Token)
+	userId, err := jwt.CheckToken(*flags.Get().JwtIssuer, *flags.Get().JwtAudience, u)
+	if err != nil {
+		logging.Ctx(c).Debug(err)
+		return c.JSONPretty(http.StatusUnauthorized, map[string]string{"message": err.Error()}, "	")
+	}
+
+	// id, item_id
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		// 404: Not found
+		return echo.ErrNotFound
+	}
+	itemId, err := strconv.ParseUint(c.Param("item_id"), 10, 64)
+	if err != nil {
+		// 404: Not found
+		return echo.ErrNotFound
+	}
+
+	// Bind request body
+	patch := new(sprint.ItemPatchBody)
+	if err = c.Bind(patch); err != nil {
+		// 400: Bad request
+		logging.Ctx(c).Debug(err)
+		return c.JSONPretty(http.StatusBadRequest, map[string]string{"message": err.Error()}, "	")
+	}
+
+	// Validate request body
+	if err = c.Validate(patch); err != nil {
+		// 422: Unprocessable entity
+		logging.Ctx(c).Debug(err)
+		return c.JSONPretty(http.StatusUnprocessableEntity, map[string]string{"message": err.Error()}, "	")
+	}
+
+	i, notFound, forbidden, sprintNotFound, err := sprint.PatchItem(c.Request().Context(), userId, id, itemId, *patch)
+	if err != nil {
+		// 500: Internal server error
+		logging.Ctx(c).Error(err)
+		return c.JSONPretty(http.StatusInternalServerError, map[string]string{"message": err.Error()}, "	")
+	}
+	if notFound {
+		// 404: Not found
+		logging.Ctx(c).Debug("item not found")
+		return echo.ErrNotFound
+	}
+	if forbidden {
+		// 403: Forbidden
+		logging.Ctx(c).Debug("no permission to update the sprint")
+		return c.JSONPretty(http.StatusForbidden, map[string]string{"message": "no permission to update the sprint"}, "	")
+	}
+	if sprintNotFound {
+		// 400: Bad request
+		logging.Ctx(c).Debugf("sprint id: %d does not exist", *patch.SprintId)
+		return c.JSONPretty(http.StatusBadRequest, map[string]string{"message": fmt.Sprintf("sprint id: %d does not exist", *patch.SprintId)}, "	")
+	}
+
+	// 200: Success
+	return c.JSONPretty(http.StatusOK, i, "	")
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"flow-sprints/flags"
+	"flow-sprints/jwt"
+	"flow-sprints/logging"
+	"flow-sprints/sprint"
+	"net/http"
+	"time"
+
+	jwtGo "github.com/dgrijalva/jwt-go"
+	"github.com/labstack/echo"
+)
+
+func GetCurrent(c echo.Context) error {
+	return lookup(c, sprint.LookupCurrent)
+}
+
+func GetNext(c echo.Context) error {
+	return lookup(c, sprint.LookupNext)
+}
+
+func GetPrevious(c echo.Context) error {
+	return lookup(c, sprint.LookupPrevious)
+}
+
+func lookup(c echo.Context, which string) error {
+	// Check token
+	u := c.Get("user").(*jwtGo.Token)
+	userId, err := jwt.CheckToken(*flags.Get().JwtIssuer, *flags.Get().JwtAudience, u)
+	if err != nil {
+		logging.Ctx(c).Debug(err)
+		return c.JSONPretty(http.StatusUnauthorized, map[string]string{"message": err.Error()}, "	")
+	}
+
+	// Bind query
+	q := new(sprint.LookupQuery)
+	if err = c.Bind(q); err != nil {
+		// 400: Bad request
+		logging.Ctx(c).Debug(err)
+		return c.JSONPretty(http.StatusBadRequest, map[string]string{"message": err.Error()}, "	")
+	}
+
+	// Validate query
+	if err = c.Validate(q); err != nil {
+		// 400: Bad request
+		logging.Ctx(c).Debug(err)
+		return c.JSONPretty(http.StatusBadRequest, map[string]string{"message": err.Error()}, "	")
+	}
+
+	// Time zone of sprints without a time zone of their project or owner
+	var loc *time.Location
+	if q.TimeZone != nil {
+		loc, err = time.LoadLocation(*q.TimeZone)
+		if err != nil {
+			// 400: Bad request
+			logging.Ctx(c).Debug(err)
+			return c.JSONPretty(http.StatusBadRequest, map[string]string{"message": err.Error()}, "	")
+		}
+	}
+
+	s, notFound, err := sprint.Lookup(c.Request().Context(), userId, which, q.ProjectId, loc)
+	if err != nil {
+		// 500: Internal server error
+		logging.Ctx(c).Error(err)
+		return c.JSONPretty(http.StatusInternalServerError, map[string]string{"message": err.Error()}, "	")
+	}
+	if notFound {
+		// 404: Not found
+		logging.Ctx(c).Debugf("no %s sprint", which)
+		return echo.ErrNotFound
+	}
+
+	// 200: Success
+	return c.JSONPretty(http.StatusOK, s, "	")
+}
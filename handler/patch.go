@@ -3,6 +3,7 @@ package handler
 import (
 	"flow-sprints/flags"
 	"flow-sprints/jwt"
+	"flow-sprints/logging"
 	"flow-sprints/sprint"
 	"flow-sprints/utils"
 	"fmt"
@@ -23,9 +24,9 @@ func Patch(c echo.Context) error {
 
 	// Check token
 	u := c.Get("user").(*jwtGo.Token)
-	userId, err := jwt.CheckToken(*flags.Get().JwtIssuer, u)
+	userId, err := jwt.CheckToken(*flags.Get().JwtIssuer, *flags.Get().JwtAudience, u)
 	if err != nil {
-		c.Logger().Debug(err)
+		logging.Ctx(c).Debug(err)
 		return c.JSONPretty(http.StatusUnauthorized, map[string]string{"message": err.Error()}, "	")
 	}
 
@@ -43,48 +44,64 @@ func Patch(c echo.Context) error {
 	patch := new(sprint.PatchBody)
 	if err = c.Bind(patch); err != nil {
 		// 400: Bad request
-		c.Logger().Debug(err)
+		logging.Ctx(c).Debug(err)
 		return c.JSONPretty(http.StatusBadRequest, map[string]string{"message": err.Error()}, "	")
 	}
 
 	// Validate request body
 	if err = c.Validate(patch); err != nil {
 		// 422: Unprocessable entity
-		c.Logger().Debug(err)
+		logging.Ctx(c).Debug(err)
 		return c.JSONPretty(http.StatusUnprocessableEntity, map[string]string{"message": err.Error()}, "	")
 	}
 
 	// Check project id
 	if patch.ProjectId.UInt64 != nil && *patch.ProjectId.UInt64 != nil {
-		status, err := utils.HttpGet(fmt.Sprintf("%s/%d", *flags.Get().ServiceUrlProjects, **patch.ProjectId.UInt64), &u.Raw)
+		// The token is forwarded to flow-projects, which only accepts JWTs
+		if u.Claims.(*jwt.JwtCustumClaims).PersonalAccessTokenId != nil {
+			// 403: Forbidden
+			logging.Ctx(c).Debug("projects cannot be checked with a personal access token")
+			return c.JSONPretty(http.StatusForbidden, map[string]string{"message": "projects cannot be checked with a personal access token"}, "	")
+		}
+		status, err := utils.HttpGetContext(c.Request().Context(), fmt.Sprintf("%s/%d", *flags.Get().ServiceUrlProjects, **patch.ProjectId.UInt64), &u.Raw)
 		if err != nil {
 			// 500: Internal server error
-			c.Logger().Error(err)
+			logging.Ctx(c).Error(err)
 			return c.JSONPretty(http.StatusInternalServerError, map[string]string{"message": err.Error()}, "	")
 		}
 		if status != http.StatusOK {
 			// 400: Bad request
-			c.Logger().Debugf("project id: %d does not exist", **patch.ProjectId.UInt64)
+			logging.Ctx(c).Debugf("project id: %d does not exist", **patch.ProjectId.UInt64)
 			return c.JSONPretty(http.StatusBadRequest, map[string]string{"message": fmt.Sprintf("project id: %d does not exist", **patch.ProjectId.UInt64)}, "	")
 		}
 	}
 
-	p, notFound, startAfterEnd, err := sprint.Patch(userId, id, *patch)
+	p, notFound, forbidden, startAfterEnd, notTeamMember, err := sprint.Patch(c.Request().Context(), userId, id, *patch)
 	if err != nil {
 		// 500: Internal server error
-		c.Logger().Error(err)
+		logging.Ctx(c).Error(err)
 		return c.JSONPretty(http.StatusInternalServerError, map[string]string{"message": err.Error()}, "	")
 	}
 	if notFound {
 		// 404: Not found
-		c.Logger().Debug("project not found")
+		logging.Ctx(c).Debug("project not found")
 		return echo.ErrNotFound
 	}
+	if forbidden {
+		// 403: Forbidden
+		logging.Ctx(c).Debug("no permission to update the sprint")
+		return c.JSONPretty(http.StatusForbidden, map[string]string{"message": "no permission to update the sprint"}, "	")
+	}
 	if startAfterEnd {
 		// 400: Bad request
-		c.Logger().Debug("`start` must before `end`")
+		logging.Ctx(c).Debug("`start` must before `end`")
 		return c.JSONPretty(http.StatusBadRequest, map[string]string{"message": "`start` must before `end`"}, "	")
 	}
+	if notTeamMember {
+		// 400: Bad request
+		logging.Ctx(c).Debugf("not a member of team id: %d", **patch.TeamId.UInt64)
+		return c.JSONPretty(http.StatusBadRequest, map[string]string{"message": fmt.Sprintf("not a member of team id: %d", **patch.TeamId.UInt64)}, "	")
+	}
 
 	// 200: Success
 	return c.JSONPretty(http.StatusOK, p, "	")
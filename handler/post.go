@@ -3,7 +3,9 @@ package handler
 import (
 	"flow-sprints/flags"
 	"flow-sprints/jwt"
+	"flow-sprints/logging"
 	"flow-sprints/sprint"
+	"flow-sprints/template"
 	"flow-sprints/utils"
 	"fmt"
 	"net/http"
@@ -22,9 +24,9 @@ func Post(c echo.Context) error {
 
 	// Check token
 	u := c.Get("user").(*jwtGo.Token)
-	userId, err := jwt.CheckToken(*flags.Get().JwtIssuer, u)
+	userId, err := jwt.CheckToken(*flags.Get().JwtIssuer, *flags.Get().JwtAudience, u)
 	if err != nil {
-		c.Logger().Debug(err)
+		logging.Ctx(c).Debug(err)
 		return c.JSONPretty(http.StatusUnauthorized, map[string]string{"message": err.Error()}, "	")
 	}
 
@@ -32,43 +34,70 @@ func Post(c echo.Context) error {
 	post := new(sprint.PostBody)
 	if err = c.Bind(post); err != nil {
 		// 400: Bad request
-		c.Logger().Debug(err)
+		logging.Ctx(c).Debug(err)
 		return c.JSONPretty(http.StatusBadRequest, map[string]string{"message": err.Error()}, "	")
 	}
 
+	// Apply template
+	if post.TemplateId != nil {
+		t, notFound, err := template.Get(c.Request().Context(), userId, *post.TemplateId)
+		if err != nil {
+			// 500: Internal server error
+			logging.Ctx(c).Error(err)
+			return c.JSONPretty(http.StatusInternalServerError, map[string]string{"message": err.Error()}, "	")
+		}
+		if notFound {
+			// 400: Bad request
+			logging.Ctx(c).Debugf("template id: %d does not exist", *post.TemplateId)
+			return c.JSONPretty(http.StatusBadRequest, map[string]string{"message": fmt.Sprintf("template id: %d does not exist", *post.TemplateId)}, "	")
+		}
+		t.Apply(post)
+	}
+
 	// Validate request body
 	if err = c.Validate(post); err != nil {
 		// 422: Unprocessable entity
-		c.Logger().Debug(err)
+		logging.Ctx(c).Debug(err)
 		return c.JSONPretty(http.StatusUnprocessableEntity, map[string]string{"message": err.Error()}, "	")
 	}
 
 	// Check project id
 	if post.ProjectId != nil {
-		status, err := utils.HttpGet(fmt.Sprintf("%s/%d", *flags.Get().ServiceUrlProjects, *post.ProjectId), &u.Raw)
+		// The token is forwarded to flow-projects, which only accepts JWTs
+		if u.Claims.(*jwt.JwtCustumClaims).PersonalAccessTokenId != nil {
+			// 403: Forbidden
+			logging.Ctx(c).Debug("projects cannot be checked with a personal access token")
+			return c.JSONPretty(http.StatusForbidden, map[string]string{"message": "projects cannot be checked with a personal access token"}, "	")
+		}
+		status, err := utils.HttpGetContext(c.Request().Context(), fmt.Sprintf("%s/%d", *flags.Get().ServiceUrlProjects, *post.ProjectId), &u.Raw)
 		if err != nil {
 			// 500: Internal server error
-			c.Logger().Error(err)
+			logging.Ctx(c).Error(err)
 			return c.JSONPretty(http.StatusInternalServerError, map[string]string{"message": err.Error()}, "	")
 		}
 		if status != http.StatusOK {
 			// 400: Bad request
-			c.Logger().Debugf("project id: %d does not exist", *post.ProjectId)
+			logging.Ctx(c).Debugf("project id: %d does not exist", *post.ProjectId)
 			return c.JSONPretty(http.StatusBadRequest, map[string]string{"message": fmt.Sprintf("project id: %d does not exist", *post.ProjectId)}, "	")
 		}
 	}
 
-	p, startAfterEnd, err := sprint.Post(userId, *post)
+	p, startAfterEnd, notTeamMember, err := sprint.Post(c.Request().Context(), userId, *post)
 	if err != nil {
 		// 500: Internal server error
-		c.Logger().Error(err)
+		logging.Ctx(c).Error(err)
 		return c.JSONPretty(http.StatusInternalServerError, map[string]string{"message": err.Error()}, "	")
 	}
 	if startAfterEnd {
 		// 400: Bad request
-		c.Logger().Debug("`start` must before `end`")
+		logging.Ctx(c).Debug("`start` must before `end`")
 		return c.JSONPretty(http.StatusBadRequest, map[string]string{"message": "`start` must before `end`"}, "	")
 	}
+	if notTeamMember {
+		// 400: Bad request
+		logging.Ctx(c).Debugf("not a member of team id: %d", *post.TeamId)
+		return c.JSONPretty(http.StatusBadRequest, map[string]string{"message": fmt.Sprintf("not a member of team id: %d", *post.TeamId)}, "	")
+	}
 
 	// 200: Success
 	return c.JSONPretty(http.StatusOK, p, "	")
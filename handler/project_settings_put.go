@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"flow-sprints/flags"
+	"flow-sprints/jwt"
+	"flow-sprints/logging"
+	"flow-sprints/settings"
+	"flow-sprints/utils"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	jwtGo "github.com/dgrijalva/jwt-go"
+	"github.com/labstack/echo"
+)
+
+func PutProjectSettings(c echo.Context) error {
+	// Check `Content-Type`
+	if !strings.Contains(c.Request().Header.Get("Content-Type"), "application/json") {
+		// 415: Invalid `Content-Type`
+		return c.JSONPretty(http.StatusUnsupportedMediaType, map[string]string{"message": "unsupported media type"}, "	")
+	}
+
+	// Check token
+	u := c.Get("user").(*jwtGo.Token)
+	_, err := jwt.CheckToken(*flags.Get().JwtIssuer, *flags.Get().JwtAudience, u)
+	if err != nil {
+		logging.Ctx(c).Debug(err)
+		return c.JSONPretty(http.StatusUnauthorized, map[string]string{"message": err.Error()}, "	")
+	}
+
+	// project_id
+	projectId, err := strconv.ParseUint(c.Param("project_id"), 10, 64)
+	if err != nil {
+		// 404: Not found
+		return echo.ErrNotFound
+	}
+
+	// Check project
+	// The token is forwarded to flow-projects, which only accepts JWTs
+	if u.Claims.(*jwt.JwtCustumClaims).PersonalAccessTokenId != nil {
+		// 403: Forbidden
+		logging.Ctx(c).Debug("projects cannot be checked with a personal access token")
+		return c.JSONPretty(http.StatusForbidden, map[string]string{"message": "projects cannot be checked with a personal access token"}, "	")
+	}
+	status, err := utils.HttpGetContext(c.Request().Context(), fmt.Sprintf("%s/%d", *flags.Get().ServiceUrlProjects, projectId), &u.Raw)
+	if err != nil {
+		// 500: Internal server error
+		logging.Ctx(c).Error(err)
+		return c.JSONPretty(http.StatusInternalServerError, map[string]string{"message": err.Error()}, "	")
+	}
+	if status != http.StatusOK {
+		// 404: Not found
+		logging.Ctx(c).Debugf("project id: %d does not exist", projectId)
+		return echo.ErrNotFound
+	}
+
+	// Bind request body
+	put := new(settings.PutBody)
+	if err = c.Bind(put); err != nil {
+		// 400: Bad request
+		logging.Ctx(c).Debug(err)
+		return c.JSONPretty(http.StatusBadRequest, map[string]string{"message": err.Error()}, "	")
+	}
+
+	// Validate request body
+	if err = c.Validate(put); err != nil {
+		// 422: Unprocessable entity
+		logging.Ctx(c).Debug(err)
+		return c.JSONPretty(http.StatusUnprocessableEntity, map[string]string{"message": err.Error()}, "	")
+	}
+
+	s, err := settings.PutProject(c.Request().Context(), projectId, *put)
+	if err != nil {
+		// 500: Internal server error
+		logging.Ctx(c).Error(err)
+		return c.JSONPretty(http.StatusInternalServerError, map[string]string{"message": err.Error()}, "	")
+	}
+
+	// 200: Success
+	return c.JSONPretty(http.StatusOK, s, "	")
+}
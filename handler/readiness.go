@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"context"
+	"flow-sprints/flags"
+	"flow-sprints/logging"
+	"flow-sprints/mysql"
+	"flow-sprints/utils"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/labstack/echo"
+)
+
+var shuttingDown int32
+
+// SetShuttingDown marks the service as unhealthy so that load balancers stop
+// routing new requests while in-flight requests are drained.
+func SetShuttingDown() {
+	atomic.StoreInt32(&shuttingDown, 1)
+}
+
+type DependencyStatus struct {
+	Status    string  `json:"status"`
+	Required  bool    `json:"required"`
+	LatencyMs float64 `json:"latency_ms"`
+}
+
+type ReadinessReport struct {
+	Status       string                      `json:"status"`
+	Version      string                      `json:"version"`
+	Dependencies map[string]DependencyStatus `json:"dependencies"`
+}
+
+func Liveness(c echo.Context) error {
+	// 200: Success
+	return c.String(http.StatusOK, "flow-sprints:v1.1.1 is Alive.\n")
+}
+
+func Readiness(c echo.Context) error {
+	report := ReadinessReport{
+		Status:       "healthy",
+		Version:      "v1.1.1",
+		Dependencies: map[string]DependencyStatus{},
+	}
+
+	if atomic.LoadInt32(&shuttingDown) == 1 {
+		// 503: Service unavailable
+		report.Status = "shutting down"
+		return c.JSONPretty(http.StatusServiceUnavailable, report, "	")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), time.Duration(*flags.Get().ReadinessTimeout)*time.Second)
+	defer cancel()
+
+	// MySQL
+	report.Dependencies["mysql"] = checkDependency(c, "mysql", true, func() error {
+		db, err := mysql.Open()
+		if err != nil {
+			return err
+		}
+		return db.PingContext(ctx)
+	})
+
+	// flow-projects
+	if *flags.Get().ReadinessCheckProjects {
+		report.Dependencies["flow-projects"] = checkDependency(c, "flow-projects", false, func() error {
+			status, err := utils.HttpGetContext(ctx, *flags.Get().ServiceUrlProjects+"/-/readiness", nil)
+			if err != nil {
+				return err
+			}
+			if status != http.StatusOK {
+				return fmt.Errorf("unexpected status %d", status)
+			}
+			return nil
+		})
+	}
+
+	for _, d := range report.Dependencies {
+		if d.Required && d.Status != "up" {
+			// 503: Service unavailable
+			report.Status = "unhealthy"
+			return c.JSONPretty(http.StatusServiceUnavailable, report, "	")
+		}
+	}
+
+	// 200: Success
+	return c.JSONPretty(http.StatusOK, report, "	")
+}
+
+// Errors are logged only, as they may reveal internal hosts and addresses.
+func checkDependency(c echo.Context, name string, required bool, check func() error) DependencyStatus {
+	start := time.Now()
+	err := check()
+	d := DependencyStatus{
+		Status:    "up",
+		Required:  required,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		logging.Ctx(c).Warnf("dependency `%s` is down: %s", name, err)
+		d.Status = "down"
+	}
+	return d
+}
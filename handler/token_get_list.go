@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"flow-sprints/flags"
+	"flow-sprints/jwt"
+	"flow-sprints/logging"
+	"flow-sprints/token"
+	"net/http"
+
+	jwtGo "github.com/dgrijalva/jwt-go"
+	"github.com/labstack/echo"
+)
+
+func GetTokenList(c echo.Context) error {
+	// Check token
+	u := c.Get("user").(*jwtGo.Token)
+	userId, err := jwt.CheckToken(*flags.Get().JwtIssuer, *flags.Get().JwtAudience, u)
+	if err != nil {
+		logging.Ctx(c).Debug(err)
+		return c.JSONPretty(http.StatusUnauthorized, map[string]string{"message": err.Error()}, "	")
+	}
+	if u.Claims.(*jwt.JwtCustumClaims).PersonalAccessTokenId != nil {
+		// 403: Forbidden
+		logging.Ctx(c).Debug("personal access tokens cannot be managed with a personal access token")
+		return c.JSONPretty(http.StatusForbidden, map[string]string{"message": "personal access tokens cannot be managed with a personal access token"}, "	")
+	}
+
+	// Get tokens
+	tokens, err := token.GetList(c.Request().Context(), userId)
+	if err != nil {
+		// 500: Internal server error
+		logging.Ctx(c).Error(err)
+		return c.JSONPretty(http.StatusInternalServerError, map[string]string{"message": err.Error()}, "	")
+	}
+
+	// 200: Success
+	if tokens == nil {
+		return c.JSONPretty(http.StatusOK, []interface{}{}, "	")
+	}
+	return c.JSONPretty(http.StatusOK, tokens, "	")
+}
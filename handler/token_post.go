@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"flow-sprints/flags"
+	"flow-sprints/jwt"
+	"flow-sprints/logging"
+	"flow-sprints/token"
+	"fmt"
+	"net/http"
+	"strings"
+
+	jwtGo "github.com/dgrijalva/jwt-go"
+	"github.com/labstack/echo"
+)
+
+func PostToken(c echo.Context) error {
+	// Check `Content-Type`
+	if !strings.Contains(c.Request().Header.Get("Content-Type"), "application/json") {
+		// 415: Invalid `Content-Type`
+		return c.JSONPretty(http.StatusUnsupportedMediaType, map[string]string{"message": "unsupported media type"}, "	")
+	}
+
+	// Check token
+	u := c.Get("user").(*jwtGo.Token)
+	userId, err := jwt.CheckToken(*flags.Get().JwtIssuer, *flags.Get().JwtAudience, u)
+	if err != nil {
+		logging.Ctx(c).Debug(err)
+		return c.JSONPretty(http.StatusUnauthorized, map[string]string{"message": err.Error()}, "	")
+	}
+	claims := u.Claims.(*jwt.JwtCustumClaims)
+	if claims.PersonalAccessTokenId != nil {
+		// 403: Forbidden
+		logging.Ctx(c).Debug("personal access tokens cannot be managed with a personal access token")
+		return c.JSONPretty(http.StatusForbidden, map[string]string{"message": "personal access tokens cannot be managed with a personal access token"}, "	")
+	}
+
+	// Bind request body
+	post := new(token.PostBody)
+	if err = c.Bind(post); err != nil {
+		// 400: Bad request
+		logging.Ctx(c).Debug(err)
+		return c.JSONPretty(http.StatusBadRequest, map[string]string{"message": err.Error()}, "	")
+	}
+
+	// Validate request body
+	if err = c.Validate(post); err != nil {
+		// 422: Unprocessable entity
+		logging.Ctx(c).Debug(err)
+		return c.JSONPretty(http.StatusUnprocessableEntity, map[string]string{"message": err.Error()}, "	")
+	}
+
+	// Scopes cannot exceed those of the JWT, which are used if omitted
+	granted := claims.GrantedScopes(*flags.Get().JwtRequireScopes)
+	if len(post.Scopes) == 0 {
+		post.Scopes = granted
+	}
+	for _, s := range post.Scopes {
+		if !contains(granted, s) {
+			// 403: Forbidden
+			logging.Ctx(c).Debugf("missing scope `%s`", s)
+			return c.JSONPretty(http.StatusForbidden, map[string]string{"message": fmt.Sprintf("missing scope `%s`", s), "missing_scope": s}, "	")
+		}
+	}
+
+	t, expired, err := token.Post(c.Request().Context(), userId, *post)
+	if err != nil {
+		// 500: Internal server error
+		logging.Ctx(c).Error(err)
+		return c.JSONPretty(http.StatusInternalServerError, map[string]string{"message": err.Error()}, "	")
+	}
+	if expired {
+		// 400: Bad request
+		logging.Ctx(c).Debug("`expires_at` must be in the future")
+		return c.JSONPretty(http.StatusBadRequest, map[string]string{"message": "`expires_at` must be in the future"}, "	")
+	}
+
+	// 200: Success
+	return c.JSONPretty(http.StatusOK, t, "	")
+}
+
+func contains(list []string, v string) bool {
+	for _, l := range list {
+		if l == v {
+			return true
+		}
+	}
+	return false
+}
@@ -1,19 +1,76 @@
 package jwt
 
 import (
+	"encoding/json"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
 )
 
 type JwtCustumClaims struct {
-	Id    uint64 `json:"id"`
-	Email string `json:"email"`
+	Id       uint64   `json:"id"`
+	Email    string   `json:"email"`
+	Audience Audience `json:"aud,omitempty"`
+	Scope    *string  `json:"scope,omitempty"`
+	Scopes   []string `json:"scopes,omitempty"`
+	// Set when authenticated with a personal access token instead of a JWT
+	PersonalAccessTokenId *uint64 `json:"-"`
 	jwt.StandardClaims
 }
 
-func CheckToken(issuer string, token *jwt.Token) (id uint64, err error) {
+// Audience is the `aud` claim, which may be a single string or an array of strings.
+type Audience []string
+
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+	var multi []string
+	if err := json.Unmarshal(data, &multi); err != nil {
+		return errors.New("invalid `aud` claim")
+	}
+	*a = multi
+	return nil
+}
+
+// Contains reports whether aud is one of the audiences.
+func (a Audience) Contains(aud string) bool {
+	for _, v := range a {
+		if v == aud {
+			return true
+		}
+	}
+	return false
+}
+
+// HasScopeClaim reports whether the token carries `scope` or `scopes`.
+func (c *JwtCustumClaims) HasScopeClaim() bool {
+	return c.Scope != nil || c.Scopes != nil
+}
+
+// HasScope reports whether scope is granted by the space separated `scope` claim or the `scopes` claim.
+func (c *JwtCustumClaims) HasScope(scope string) bool {
+	if c.Scope != nil {
+		for _, s := range strings.Fields(*c.Scope) {
+			if s == scope {
+				return true
+			}
+		}
+	}
+	for _, s := range c.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckToken verifies the issuer, expiry and, if `audience` is not empty, the audience of token.
+func CheckToken(issuer string, audience string, token *jwt.Token) (id uint64, err error) {
 	claims := token.Claims.(*JwtCustumClaims)
 
 	if !claims.VerifyIssuer(issuer, true) {
@@ -21,6 +78,11 @@ func CheckToken(issuer string, token *jwt.Token) (id uint64, err error) {
 		return 0, errors.New("invalid token")
 	}
 
+	if audience != "" && !claims.Audience.Contains(audience) {
+		// Invalid audience
+		return 0, errors.New("invalid audience")
+	}
+
 	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
 		// Token expired
 		return 0, errors.New("token expired")
@@ -0,0 +1,212 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// KeySet provides the keys used to verify token signatures.
+type KeySet struct {
+	algorithm string
+
+	// Single key of HMAC and PEM sets (`kid` is ignored)
+	key interface{}
+
+	// Keys of JWKS sets
+	mu   sync.RWMutex
+	keys map[string]interface{} // kid -> key
+}
+
+// NewHMACKeySet returns a key set verifying HS256 tokens with secret.
+func NewHMACKeySet(secret string) *KeySet {
+	return &KeySet{
+		algorithm: "HS256",
+		key:       []byte(secret),
+	}
+}
+
+// NewPEMKeySet returns a key set verifying RS256 or ES256 tokens with the public key in the PEM file.
+func NewPEMKeySet(algorithm string, path string) (*KeySet, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var key interface{}
+	switch algorithm {
+	case "RS256":
+		key, err = jwt.ParseRSAPublicKeyFromPEM(b)
+	case "ES256":
+		key, err = jwt.ParseECPublicKeyFromPEM(b)
+	default:
+		err = fmt.Errorf("unsupported algorithm `%s`", algorithm)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &KeySet{
+		algorithm: algorithm,
+		key:       key,
+	}, nil
+}
+
+// NewJWKSKeySet returns a key set verifying RS256 or ES256 tokens with keys fetched from the JWKS url.
+// Keys are refreshed every `refresh` until ctx is done.
+func NewJWKSKeySet(ctx context.Context, algorithm string, url string, refresh time.Duration, onError func(error)) (*KeySet, error) {
+	if algorithm != "RS256" && algorithm != "ES256" {
+		return nil, fmt.Errorf("unsupported algorithm `%s`", algorithm)
+	}
+	ks := &KeySet{algorithm: algorithm}
+	if err := ks.fetch(ctx, url); err != nil {
+		return nil, err
+	}
+
+	go func() {
+		ticker := time.NewTicker(refresh)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := ks.fetch(ctx, url); err != nil && onError != nil {
+					onError(err)
+				}
+			}
+		}
+	}()
+	return ks, nil
+}
+
+// Keyfunc selects the key by `kid` after checking the signing algorithm.
+// Sets of a single key (HMAC, PEM) ignore `kid`.
+func (ks *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	if token.Method.Alg() != ks.algorithm {
+		return nil, fmt.Errorf("unexpected signing method `%s`", token.Method.Alg())
+	}
+	if ks.key != nil {
+		return ks.key, nil
+	}
+
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	kid, _ := token.Header["kid"].(string)
+	if key, ok := ks.keys[kid]; ok {
+		return key, nil
+	}
+	if kid == "" && len(ks.keys) == 1 {
+		for _, key := range ks.keys {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown key id `%s`", kid)
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (ks *KeySet) fetch(ctx context.Context, url string) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+	res, err := new(http.Client).Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch JWKS: status %d", res.StatusCode)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&set); err != nil {
+		return fmt.Errorf("failed to parse JWKS: %w", err)
+	}
+
+	keys := map[string]interface{}{}
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		if k.Alg != "" && k.Alg != ks.algorithm {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			return fmt.Errorf("failed to parse JWK `%s`: %w", k.Kid, err)
+		}
+		if key != nil {
+			keys[k.Kid] = key
+		}
+	}
+	if len(keys) == 0 {
+		return errors.New("no usable key in JWKS")
+	}
+
+	ks.mu.Lock()
+	ks.keys = keys
+	ks.mu.Unlock()
+	return nil
+}
+
+// Returns nil key for unsupported key types
+func (k jwk) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, nil
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,64 @@
+package jwt
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/labstack/echo"
+)
+
+// PersonalAccessTokenResolver resolves a bearer token which is not a JWT.
+// ok is false if raw is not a personal access token, in which case it is parsed as a JWT.
+// claims is nil if raw is a personal access token that does not exist or has expired.
+type PersonalAccessTokenResolver func(ctx context.Context, raw string) (claims *JwtCustumClaims, ok bool, err error)
+
+// Middleware verifies the bearer token with keys and stores it into context as `user`.
+// Personal access tokens are accepted when pat is not nil and stored as a token with the resolved claims.
+func Middleware(keys *KeySet, pat PersonalAccessTokenResolver, skipper func(c echo.Context) bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if skipper != nil && skipper(c) {
+				return next(c)
+			}
+
+			auth := c.Request().Header.Get(echo.HeaderAuthorization)
+			if !strings.HasPrefix(auth, "Bearer ") || len(auth) == len("Bearer ") {
+				// 400: Bad request
+				return echo.NewHTTPError(http.StatusBadRequest, "missing or malformed jwt")
+			}
+			raw := strings.TrimPrefix(auth, "Bearer ")
+
+			// Personal access token
+			if pat != nil {
+				claims, ok, err := pat(c.Request().Context(), raw)
+				if err != nil {
+					// 500: Internal server error
+					return err
+				}
+				if ok {
+					if claims == nil {
+						// 401: Unauthorized
+						return echo.NewHTTPError(http.StatusUnauthorized, "invalid or expired token")
+					}
+					c.Set("user", &jwt.Token{Raw: raw, Claims: claims, Valid: true})
+					return next(c)
+				}
+			}
+
+			token, err := jwt.ParseWithClaims(raw, &JwtCustumClaims{}, keys.Keyfunc)
+			if err != nil || !token.Valid {
+				// 401: Unauthorized
+				return &echo.HTTPError{
+					Code:     http.StatusUnauthorized,
+					Message:  "invalid or expired jwt",
+					Internal: err,
+				}
+			}
+
+			c.Set("user", token)
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package jwt
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/labstack/echo"
+)
+
+// Scopes
+const (
+	ScopeSprintsRead      = "sprints:read"
+	ScopeSprintsWrite     = "sprints:write"
+	ScopeSprintsDeleteAll = "sprints:delete-all"
+)
+
+// AllScopes lists every scope.
+var AllScopes = []string{ScopeSprintsRead, ScopeSprintsWrite, ScopeSprintsDeleteAll}
+
+// GrantedScopes returns the scopes granted by the claims.
+// Unless strict is true, claims without any `scope`/`scopes` claim are granted all scopes.
+func (c *JwtCustumClaims) GrantedScopes(strict bool) []string {
+	if !strict && !c.HasScopeClaim() {
+		return AllScopes
+	}
+	granted := []string{}
+	for _, s := range AllScopes {
+		if c.HasScope(s) {
+			granted = append(granted, s)
+		}
+	}
+	return granted
+}
+
+// RequireScope rejects tokens which do not grant scope with 403.
+// Unless strict is true, tokens without any `scope`/`scopes` claim are granted all scopes.
+func RequireScope(scope string, strict bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			token, ok := c.Get("user").(*jwt.Token)
+			if !ok {
+				// 401: Unauthorized
+				return c.JSONPretty(http.StatusUnauthorized, map[string]string{"message": "invalid or expired jwt"}, "	")
+			}
+			claims := token.Claims.(*JwtCustumClaims)
+
+			if !strict && !claims.HasScopeClaim() {
+				return next(c)
+			}
+			if !claims.HasScope(scope) {
+				// 403: Forbidden
+				return c.JSONPretty(http.StatusForbidden, map[string]string{"message": fmt.Sprintf("missing scope `%s`", scope), "missing_scope": scope}, "	")
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+const redacted = "********"
+
+// Logger is a structured implementation of echo.Logger.
+// Each line is written as a single JSON object or logfmt record.
+type Logger struct {
+	mu     *sync.Mutex
+	out    io.Writer
+	format string
+	prefix string
+	level  *uint32 // log.Lvl, shared with child loggers and changed on reload
+	fields log.JSON
+}
+
+var (
+	secretsMu sync.RWMutex
+	secrets   []string
+)
+
+// AddSecret registers a value which must never appear in log output.
+func AddSecret(s string) {
+	if s == "" {
+		return
+	}
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+	secrets = append(secrets, s)
+}
+
+func redact(s string) string {
+	secretsMu.RLock()
+	defer secretsMu.RUnlock()
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	return s
+}
+
+// New returns a logger writing to stdout.
+// format is one of "json" or "logfmt".
+func New(format string, prefix string) (*Logger, error) {
+	if format != "json" && format != "logfmt" {
+		return nil, fmt.Errorf("unknown log format `%s`", format)
+	}
+	lvl := uint32(log.INFO)
+	return &Logger{
+		mu:     new(sync.Mutex),
+		out:    os.Stdout,
+		format: format,
+		prefix: prefix,
+		level:  &lvl,
+		fields: log.JSON{},
+	}, nil
+}
+
+// With returns a child logger which adds fields to every line.
+func (l *Logger) With(fields log.JSON) *Logger {
+	merged := log.JSON{}
+	for k, v := range l.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	child := *l
+	child.fields = merged
+	return &child
+}
+
+// Access writes an access log line regardless of the log level.
+func (l *Logger) Access(fields log.JSON) {
+	l.write("access", "", fields)
+}
+
+func (l *Logger) write(level string, msg string, fields log.JSON) {
+	record := log.JSON{}
+	for k, v := range l.fields {
+		record[k] = v
+	}
+	for k, v := range fields {
+		record[k] = v
+	}
+	record["time"] = time.Now().Format(time.RFC3339)
+	record["level"] = level
+	if l.prefix != "" {
+		record["prefix"] = l.prefix
+	}
+	if msg != "" {
+		record["message"] = msg
+	}
+
+	var line string
+	if l.format == "json" {
+		b, err := json.Marshal(record)
+		if err != nil {
+			b = []byte(strconv.Quote(err.Error()))
+		}
+		line = string(b)
+	} else {
+		keys := make([]string, 0, len(record))
+		for k := range record {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		pairs := make([]string, 0, len(keys))
+		for _, k := range keys {
+			v := fmt.Sprint(record[k])
+			if strings.ContainsAny(v, " =\"\t\n") || v == "" {
+				v = strconv.Quote(v)
+			}
+			pairs = append(pairs, k+"="+v)
+		}
+		line = strings.Join(pairs, " ")
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fmt.Fprintln(l.out, redact(line))
+}
+
+func (l *Logger) log(lvl log.Lvl, name string, msg string, j log.JSON) {
+	if lvl < l.Level() {
+		return
+	}
+	l.write(name, msg, j)
+}
+
+// Implements echo.Logger
+
+func (l *Logger) Output() io.Writer      { return l.out }
+func (l *Logger) SetOutput(w io.Writer)  { l.out = w }
+func (l *Logger) Prefix() string         { return l.prefix }
+func (l *Logger) SetPrefix(p string)     { l.prefix = p }
+func (l *Logger) Level() log.Lvl         { return log.Lvl(atomic.LoadUint32(l.level)) }
+func (l *Logger) SetLevel(v log.Lvl)     { atomic.StoreUint32(l.level, uint32(v)) }
+func (l *Logger) SetHeader(h string)     {}
+func (l *Logger) Print(i ...interface{}) { l.write("-", fmt.Sprint(i...), nil) }
+func (l *Logger) Printf(format string, args ...interface{}) {
+	l.write("-", fmt.Sprintf(format, args...), nil)
+}
+func (l *Logger) Printj(j log.JSON)      { l.write("-", "", j) }
+func (l *Logger) Debug(i ...interface{}) { l.log(log.DEBUG, "debug", fmt.Sprint(i...), nil) }
+func (l *Logger) Debugf(format string, args ...interface{}) {
+	l.log(log.DEBUG, "debug", fmt.Sprintf(format, args...), nil)
+}
+func (l *Logger) Debugj(j log.JSON)     { l.log(log.DEBUG, "debug", "", j) }
+func (l *Logger) Info(i ...interface{}) { l.log(log.INFO, "info", fmt.Sprint(i...), nil) }
+func (l *Logger) Infof(format string, args ...interface{}) {
+	l.log(log.INFO, "info", fmt.Sprintf(format, args...), nil)
+}
+func (l *Logger) Infoj(j log.JSON)      { l.log(log.INFO, "info", "", j) }
+func (l *Logger) Warn(i ...interface{}) { l.log(log.WARN, "warn", fmt.Sprint(i...), nil) }
+func (l *Logger) Warnf(format string, args ...interface{}) {
+	l.log(log.WARN, "warn", fmt.Sprintf(format, args...), nil)
+}
+func (l *Logger) Warnj(j log.JSON)       { l.log(log.WARN, "warn", "", j) }
+func (l *Logger) Error(i ...interface{}) { l.log(log.ERROR, "error", fmt.Sprint(i...), nil) }
+func (l *Logger) Errorf(format string, args ...interface{}) {
+	l.log(log.ERROR, "error", fmt.Sprintf(format, args...), nil)
+}
+func (l *Logger) Errorj(j log.JSON) { l.log(log.ERROR, "error", "", j) }
+func (l *Logger) Fatal(i ...interface{}) {
+	l.write("fatal", fmt.Sprint(i...), nil)
+	os.Exit(1)
+}
+func (l *Logger) Fatalj(j log.JSON) {
+	l.write("fatal", "", j)
+	os.Exit(1)
+}
+func (l *Logger) Fatalf(format string, args ...interface{}) {
+	l.write("fatal", fmt.Sprintf(format, args...), nil)
+	os.Exit(1)
+}
+func (l *Logger) Panic(i ...interface{}) {
+	msg := fmt.Sprint(i...)
+	l.write("panic", msg, nil)
+	panic(msg)
+}
+func (l *Logger) Panicj(j log.JSON) {
+	l.write("panic", "", j)
+	panic(j)
+}
+func (l *Logger) Panicf(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	l.write("panic", msg, nil)
+	panic(msg)
+}
@@ -0,0 +1,80 @@
+package logging
+
+import (
+	"flow-sprints/jwt"
+	"time"
+
+	jwtGo "github.com/dgrijalva/jwt-go"
+	"github.com/labstack/echo"
+	"github.com/labstack/gommon/log"
+)
+
+const (
+	startKey  = "logging.start"
+	loggerKey = "logging.logger"
+)
+
+// Middleware records the request start time and writes an access log line
+// for each request when accessLog is true.
+func Middleware(base *Logger, accessLog bool, skipper func(c echo.Context) bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			c.Set(startKey, start)
+			c.Set(loggerKey, base)
+
+			err := next(c)
+			if err != nil {
+				// Let echo write the error response so the status is logged correctly
+				c.Error(err)
+			}
+
+			if accessLog && (skipper == nil || !skipper(c)) {
+				req := c.Request()
+				res := c.Response()
+				base.Access(fields(c, log.JSON{
+					"method":        req.Method,
+					"uri":           req.RequestURI,
+					"status":        res.Status,
+					"size":          res.Size,
+					"remote_ip":     c.RealIP(),
+					"user_agent":    req.UserAgent(),
+					"referer":       req.Referer(),
+					"forwarded_for": req.Header.Get(echo.HeaderXForwardedFor),
+				}))
+			}
+			return nil
+		}
+	}
+}
+
+// Ctx returns a logger carrying request-scoped fields:
+// request ID, user ID (if authenticated), route and latency so far.
+func Ctx(c echo.Context) echo.Logger {
+	base, ok := c.Get(loggerKey).(*Logger)
+	if !ok {
+		return c.Logger()
+	}
+	return base.With(fields(c, nil))
+}
+
+func fields(c echo.Context, extra log.JSON) log.JSON {
+	f := log.JSON{
+		"request_id": c.Response().Header().Get(echo.HeaderXRequestID),
+		"route":      c.Path(),
+	}
+	if start, ok := c.Get(startKey).(time.Time); ok {
+		latency := time.Since(start)
+		f["latency_ns"] = latency.Nanoseconds()
+		f["latency_human"] = latency.String()
+	}
+	if token, ok := c.Get("user").(*jwtGo.Token); ok {
+		if claims, ok := token.Claims.(*jwt.JwtCustumClaims); ok {
+			f["user_id"] = claims.Id
+		}
+	}
+	for k, v := range extra {
+		f[k] = v
+	}
+	return f
+}
@@ -1,15 +1,26 @@
 package main
 
 import (
+	"context"
 	"flow-sprints/flags"
 	"flow-sprints/handler"
 	"flow-sprints/jwt"
+	"flow-sprints/logging"
+	"flow-sprints/metrics"
 	"flow-sprints/mysql"
+	"flow-sprints/ratelimit"
 	"flow-sprints/sprint"
+	"flow-sprints/token"
+	"flow-sprints/tracing"
 	"flow-sprints/utils"
 	"fmt"
 	"net/http"
 	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+	_ "time/tzdata"
 
 	"github.com/go-playground/validator"
 	"github.com/labstack/echo"
@@ -24,6 +35,9 @@ type CustomValidator struct {
 func (cv *CustomValidator) Validate(i interface{}) error {
 	// Register custum validations
 	cv.validator.RegisterValidation("Y-M-D", sprint.DateStrValidation)
+	cv.validator.RegisterValidation("RFC3339", token.DatetimeStrValidation)
+	cv.validator.RegisterValidation("TZ", sprint.TimeZoneValidation)
+	cv.validator.RegisterValidation("H:M", sprint.TimeOfDayStrValidation)
 
 	if err := cv.validator.Struct(i); err != nil {
 		return err
@@ -31,33 +45,42 @@ func (cv *CustomValidator) Validate(i interface{}) error {
 	return nil
 }
 
-func logFormat() string {
-	// Refer to https://github.com/tkuchiki/alp
-	var format string
-	format += "time:${time_rfc3339}\t"
-	format += "host:${remote_ip}\t"
-	format += "forwardedfor:${header:x-forwarded-for}\t"
-	format += "req:-\t"
-	format += "status:${status}\t"
-	format += "method:${method}\t"
-	format += "uri:${uri}\t"
-	format += "size:${bytes_out}\t"
-	format += "referer:${referer}\t"
-	format += "ua:${user_agent}\t"
-	format += "reqtime_ns:${latency}\t"
-	format += "cache:-\t"
-	format += "runtime:-\t"
-	format += "apptime:-\t"
-	format += "vhost:${host}\t"
-	format += "reqtime_human:${latency_human}\t"
-	format += "x-request-id:${id}\t"
-	format += "host:${host}\n"
-	return format
+// CORS middleware whose allow origins can be replaced at runtime
+type reloadableCORS struct {
+	mw atomic.Value // echo.MiddlewareFunc
+}
+
+func newReloadableCORS(origins []string) *reloadableCORS {
+	r := &reloadableCORS{}
+	r.set(origins)
+	return r
+}
+
+func (r *reloadableCORS) set(origins []string) {
+	if len(origins) == 0 {
+		// CORS disabled
+		r.mw.Store(echo.MiddlewareFunc(func(next echo.HandlerFunc) echo.HandlerFunc { return next }))
+		return
+	}
+	r.mw.Store(middleware.CORSWithConfig(middleware.CORSConfig{
+		AllowOrigins: origins,
+		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
+	}))
+}
+
+func (r *reloadableCORS) middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return r.mw.Load().(echo.MiddlewareFunc)(next)(c)
+	}
 }
 
 func main() {
-	// Get command line params / env variables
+	// Get command line params / env variables / config file
 	f := flags.Get()
+	if err := flags.Validate(f); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
 
 	//
 	// Setup echo and middlewares
@@ -66,10 +89,48 @@ func main() {
 	// Echo instance
 	e := echo.New()
 
+	// Structured logger
+	logger, err := logging.New(*f.LogFormat, "flow-sprints")
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+	logging.AddSecret(*f.MysqlPasswd)
+	logging.AddSecret(*f.JwtSecret)
+	e.Logger = logger
+
 	// Log level
 	e.Logger.SetLevel(log.Lvl(*f.LogLevel))
 	e.Logger.Infof("Log level %d", *f.LogLevel)
 
+	// Tracing
+	shutdownTracing, err := tracing.Init(*f.TraceExporter, *f.TraceEndpoint, *f.TraceInsecure)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+	e.Use(tracing.Middleware(func(c echo.Context) bool {
+		return c.Path() == "/-/liveness" || c.Path() == "/-/readiness" || c.Path() == "/-/metrics"
+	}))
+	e.Logger.Infof("Tracing enabled with exporter `%s`", *f.TraceExporter)
+
+	// Metrics
+	e.Use(metrics.Middleware(func(c echo.Context) bool {
+		return c.Path() == "/-/metrics"
+	}))
+
+	// Request ID
+	e.Use(middleware.RequestID())
+
+	// Logger
+	e.Use(logging.Middleware(logger, *f.AccessLog, func(c echo.Context) bool {
+		return c.Path() == "/-/liveness" || c.Path() == "/-/readiness" || c.Path() == "/-/metrics"
+	}))
+	if *f.AccessLog {
+		e.Logger.Info("Access logging enabled")
+	}
+
+	// Body limit
+	e.Use(middleware.BodyLimit(*f.BodyLimit))
+
 	// Gzip
 	e.Use(middleware.GzipWithConfig(middleware.GzipConfig{
 		Level: int(*f.GzipLevel),
@@ -77,35 +138,56 @@ func main() {
 	e.Logger.Infof("Gzip enabled with level %d", *f.GzipLevel)
 
 	// CORS
-	if f.AllowOrigins != nil && len(f.AllowOrigins) != 0 {
-		e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-			AllowOrigins: f.AllowOrigins,
-			AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
-		}))
+	cors := newReloadableCORS(f.AllowOrigins)
+	e.Use(cors.middleware)
+	if len(f.AllowOrigins) != 0 {
 		e.Logger.Info("CORS enabled")
 		e.Logger.Debugf("CORS allow origins %s", f.AllowOrigins.String())
 	}
 
+	// Client IP of rate limits (validated above)
+	proxies, _ := ratelimit.ParseProxies(*f.TrustedProxies)
+
+	// Rate limit per IP (before authentication)
+	if *f.RateLimitIp != 0 {
+		e.Use(ratelimit.IPMiddleware(ratelimit.NewLimiter(*f.RateLimitIp, time.Minute), proxies, func(c echo.Context) bool {
+			return c.Path() == "/-/liveness" || c.Path() == "/-/readiness" || c.Path() == "/-/metrics"
+		}))
+		e.Logger.Infof("Rate limit enabled with %d requests per minute per IP", *f.RateLimitIp)
+	}
+
 	// JWT
-	e.Use(middleware.JWTWithConfig(middleware.JWTConfig{
-		Claims:     &jwt.JwtCustumClaims{},
-		SigningKey: []byte(*f.JwtSecret),
-		Skipper: func(c echo.Context) bool {
-			return c.Path() == "/-/readiness"
-		},
+	var keys *jwt.KeySet
+	switch {
+	case *f.JwtAlgorithm == "HS256":
+		keys = jwt.NewHMACKeySet(*f.JwtSecret)
+	case *f.JwtPublicKeyFile != "":
+		keys, err = jwt.NewPEMKeySet(*f.JwtAlgorithm, *f.JwtPublicKeyFile)
+	default:
+		keys, err = jwt.NewJWKSKeySet(context.Background(), *f.JwtAlgorithm, *f.JwtJwksUrl, time.Duration(*f.JwtJwksRefresh)*time.Second, func(err error) {
+			e.Logger.Warnf("failed to refresh JWKS: %s", err)
+		})
+	}
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+	e.Use(jwt.Middleware(keys, token.Resolver(*f.JwtIssuer, *f.JwtAudience), func(c echo.Context) bool {
+		return c.Path() == "/-/liveness" || c.Path() == "/-/readiness" || c.Path() == "/-/metrics"
 	}))
+	e.Logger.Infof("JWT verification with %s enabled", *f.JwtAlgorithm)
 
-	// Logger
-	if f.LogLevel != nil && *f.LogLevel == 1 {
-		e.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
-			Format: logFormat(),
-			Output: os.Stdout,
-			Skipper: func(c echo.Context) bool {
-				return c.Path() == "/-/readiness"
-			},
-		}))
-		e.Logger.Info("Access logging with `alp`(https://github.com/tkuchiki/alp) enabled")
+	// Rate limit per user
+	var readLimiter, writeLimiter *ratelimit.Limiter
+	if *f.RateLimitRead != 0 {
+		readLimiter = ratelimit.NewLimiter(*f.RateLimitRead, time.Minute)
 	}
+	if *f.RateLimitWrite != 0 {
+		writeLimiter = ratelimit.NewLimiter(*f.RateLimitWrite, time.Minute)
+	}
+	e.Use(ratelimit.Middleware(readLimiter, writeLimiter, proxies, func(c echo.Context) bool {
+		return c.Path() == "/-/liveness" || c.Path() == "/-/readiness" || c.Path() == "/-/metrics"
+	}))
+	e.Logger.Infof("Rate limit enabled with %d reads / %d writes per minute", *f.RateLimitRead, *f.RateLimitWrite)
 
 	// Validator instance
 	e.Validator = &CustomValidator{validator: validator.New()}
@@ -115,6 +197,9 @@ func main() {
 	//
 
 	// DB client instance
+	// Time zone of sprints whose project and owner have none (validated above)
+	sprint.SetDefaultTimeZone(*f.TimeZone)
+
 	e.Logger.Debugf("DB DSN `%s`", mysql.SetDSNTCP(*f.MysqlUser, *f.MysqlPasswd, *f.MysqlHost, int(*f.MysqlPort), *f.MysqlDB))
 
 	// Check connection
@@ -127,6 +212,22 @@ func main() {
 	}
 	e.Logger.Info("DB connection test succeeded")
 
+	// Metrics of DB
+	if err = metrics.RegisterDB(d, *f.MysqlDB); err != nil {
+		e.Logger.Fatal(err)
+	}
+	err = metrics.RegisterGauge("active_sprints", "Number of sprints in progress, each in its own time zone.", func() float64 {
+		count, err := sprint.CountActive(context.Background())
+		if err != nil {
+			e.Logger.Error(err)
+			return 0
+		}
+		return float64(count)
+	})
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+
 	//
 	// Check health of external service
 	//
@@ -144,21 +245,125 @@ func main() {
 	// Routes
 	//
 
-	// Health check route
-	e.GET("/-/readiness", func(c echo.Context) error {
-		return c.String(http.StatusOK, "flow-sprints:v1.1.1 is Healthy.\n")
-	})
+	// Health check routes
+	e.GET("/-/liveness", handler.Liveness)
+	e.GET("/-/readiness", handler.Readiness)
+	e.GET("/-/metrics", metrics.Handler())
 
 	// Restricted routes
-	e.GET("/", handler.GetList)
-	e.POST("/", handler.Post)
-	e.GET(":id", handler.Get)
-	e.PATCH(":id", handler.Patch)
-	e.DELETE(":id", handler.Delete)
-	e.DELETE("/", handler.DeleteAll)
+	scope := func(s string) echo.MiddlewareFunc {
+		return jwt.RequireScope(s, *f.JwtRequireScopes)
+	}
+	e.GET("/", handler.GetList, scope(jwt.ScopeSprintsRead))
+	e.POST("/", handler.Post, scope(jwt.ScopeSprintsWrite))
+	e.GET("/current", handler.GetCurrent, scope(jwt.ScopeSprintsRead))
+	e.GET("/next", handler.GetNext, scope(jwt.ScopeSprintsRead))
+	e.GET("/previous", handler.GetPrevious, scope(jwt.ScopeSprintsRead))
+	e.GET("/settings", handler.GetSettings, scope(jwt.ScopeSprintsRead))
+	e.PUT("/settings", handler.PutSettings, scope(jwt.ScopeSprintsWrite))
+	e.GET("/projects/:project_id/settings", handler.GetProjectSettings, scope(jwt.ScopeSprintsRead))
+	e.PUT("/projects/:project_id/settings", handler.PutProjectSettings, scope(jwt.ScopeSprintsWrite))
+	e.GET("/projects/:project_id/sprints/:number", handler.GetByNumber, scope(jwt.ScopeSprintsRead))
+	e.POST("/templates", handler.PostTemplate, scope(jwt.ScopeSprintsWrite))
+	e.GET("/templates", handler.GetTemplateList, scope(jwt.ScopeSprintsRead))
+	e.GET("/templates/:id", handler.GetTemplate, scope(jwt.ScopeSprintsRead))
+	e.PATCH("/templates/:id", handler.PatchTemplate, scope(jwt.ScopeSprintsWrite))
+	e.DELETE("/templates/:id", handler.DeleteTemplate, scope(jwt.ScopeSprintsWrite))
+	e.GET("/reports/velocity", handler.GetVelocity, scope(jwt.ScopeSprintsRead))
+	e.GET(":id", handler.Get, scope(jwt.ScopeSprintsRead))
+	e.PATCH(":id", handler.Patch, scope(jwt.ScopeSprintsWrite))
+	e.DELETE(":id", handler.Delete, scope(jwt.ScopeSprintsWrite))
+	e.POST(":id/clone", handler.Clone, scope(jwt.ScopeSprintsWrite))
+	e.POST(":id/reschedule", handler.Reschedule, scope(jwt.ScopeSprintsWrite))
+	e.DELETE("/", handler.DeleteAll, scope(jwt.ScopeSprintsDeleteAll))
+	e.GET(":id/shares", handler.GetShareList, scope(jwt.ScopeSprintsRead))
+	e.PUT(":id/shares/:user_id", handler.PutShare, scope(jwt.ScopeSprintsWrite))
+	e.DELETE(":id/shares/:user_id", handler.DeleteShare, scope(jwt.ScopeSprintsWrite))
+	e.GET(":id/items", handler.GetItemList, scope(jwt.ScopeSprintsRead))
+	e.POST(":id/items", handler.PostItem, scope(jwt.ScopeSprintsWrite))
+	e.GET(":id/items/:item_id", handler.GetItem, scope(jwt.ScopeSprintsRead))
+	e.PATCH(":id/items/:item_id", handler.PatchItem, scope(jwt.ScopeSprintsWrite))
+	e.DELETE(":id/items/:item_id", handler.DeleteItem, scope(jwt.ScopeSprintsWrite))
+	e.GET(":id/capacity", handler.GetCapacity, scope(jwt.ScopeSprintsRead))
+	e.PUT(":id/capacity/:user_id", handler.PutCapacity, scope(jwt.ScopeSprintsWrite))
+	e.DELETE(":id/capacity/:user_id", handler.DeleteCapacity, scope(jwt.ScopeSprintsWrite))
+	e.GET(":id/retro", handler.GetRetroList, scope(jwt.ScopeSprintsRead))
+	e.POST(":id/retro", handler.PostRetro, scope(jwt.ScopeSprintsWrite))
+	e.PATCH(":id/retro/:entry_id", handler.PatchRetro, scope(jwt.ScopeSprintsWrite))
+	e.DELETE(":id/retro/:entry_id", handler.DeleteRetro, scope(jwt.ScopeSprintsWrite))
+	e.PUT(":id/retro/:entry_id/vote", handler.PutRetroVote, scope(jwt.ScopeSprintsWrite))
+	e.DELETE(":id/retro/:entry_id/vote", handler.DeleteRetroVote, scope(jwt.ScopeSprintsWrite))
+	e.POST(":id/retro/carry-actions", handler.CarryRetroActions, scope(jwt.ScopeSprintsWrite))
+	e.GET(":id/standups", handler.GetStandupList, scope(jwt.ScopeSprintsRead))
+	e.POST(":id/standups", handler.PostStandup, scope(jwt.ScopeSprintsWrite))
+	e.GET(":id/standups/digest", handler.GetStandupDigest, scope(jwt.ScopeSprintsRead))
+	e.DELETE(":id/standups/:date", handler.DeleteStandup, scope(jwt.ScopeSprintsWrite))
+	e.GET(":id/burndown", handler.GetBurndown, scope(jwt.ScopeSprintsRead))
+	e.POST(":id/carry-over", handler.CarryOver, scope(jwt.ScopeSprintsWrite))
+	e.GET(":id/carry-overs", handler.GetCarryOverList, scope(jwt.ScopeSprintsRead))
+
+	// Teams
+	e.POST("/teams", handler.PostTeam, scope(jwt.ScopeSprintsWrite))
+	e.GET("/teams", handler.GetTeamList, scope(jwt.ScopeSprintsRead))
+	e.GET("/teams/:id", handler.GetTeam, scope(jwt.ScopeSprintsRead))
+	e.PATCH("/teams/:id", handler.PatchTeam, scope(jwt.ScopeSprintsWrite))
+	e.DELETE("/teams/:id", handler.DeleteTeam, scope(jwt.ScopeSprintsWrite))
+	e.GET("/teams/:id/members", handler.GetTeamMemberList, scope(jwt.ScopeSprintsRead))
+	e.PUT("/teams/:id/members/:user_id", handler.PutTeamMember, scope(jwt.ScopeSprintsWrite))
+	e.DELETE("/teams/:id/members/:user_id", handler.DeleteTeamMember, scope(jwt.ScopeSprintsWrite))
+	e.POST("/teams/:id/sprints", handler.MoveSprintsToTeam, scope(jwt.ScopeSprintsWrite))
+
+	// Personal access tokens
+	e.POST("/tokens", handler.PostToken, scope(jwt.ScopeSprintsWrite))
+	e.GET("/tokens", handler.GetTokenList, scope(jwt.ScopeSprintsRead))
+	e.DELETE("/tokens/:id", handler.DeleteToken, scope(jwt.ScopeSprintsWrite))
 
 	//
 	// Start echo
 	//
-	e.Logger.Fatal(e.Start(fmt.Sprintf(":%d", *f.Port)))
+	go func() {
+		if err := e.Start(fmt.Sprintf(":%d", *f.Port)); err != nil && err != http.ErrServerClosed {
+			e.Logger.Fatal(err)
+		}
+	}()
+
+	//
+	// Graceful shutdown
+	//
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	for sig := <-quit; sig == syscall.SIGHUP; sig = <-quit {
+		// Reload settings which are safe to change at runtime
+		nf, err := flags.Reload()
+		if err != nil {
+			e.Logger.Errorf("failed to reload config: %s", err)
+			continue
+		}
+		e.Logger.SetLevel(log.Lvl(*nf.LogLevel))
+		cors.set(nf.AllowOrigins)
+		e.Logger.Infof("Config reloaded (log level %d, CORS allow origins %s)", *nf.LogLevel, nf.AllowOrigins.String())
+	}
+	e.Logger.Info("Shutting down")
+
+	// Report unhealthy and wait for load balancers to stop routing new requests
+	handler.SetShuttingDown()
+	time.Sleep(time.Duration(*f.ShutdownDelay) * time.Second)
+
+	// Drain in-flight requests
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(*f.ShutdownTimeout)*time.Second)
+	defer cancel()
+	if err := e.Shutdown(ctx); err != nil {
+		e.Logger.Error(err)
+	}
+
+	// Flush spans
+	if err := shutdownTracing(ctx); err != nil {
+		e.Logger.Error(err)
+	}
+
+	// Close DB connections
+	if err := mysql.Close(); err != nil {
+		e.Logger.Error(err)
+	}
+	e.Logger.Info("Shutdown completed")
 }
@@ -0,0 +1,111 @@
+package metrics
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "flow_sprints"
+
+var (
+	registry = prometheus.NewRegistry()
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latencies by route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	storeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "store_duration_seconds",
+		Help:      "Latencies of store operations in package `sprint`.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation", "result"})
+
+	outboundRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "outbound_requests_total",
+		Help:      "Outbound HTTP requests (e.g. to flow-projects) by host and status.",
+	}, []string{"host", "status"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequestDuration,
+		storeDuration,
+		outboundRequests,
+	)
+}
+
+// RegisterDB exposes connection pool stats of db.
+func RegisterDB(db *sql.DB, name string) error {
+	return registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// RegisterGauge exposes a gauge evaluated by f on every scrape.
+func RegisterGauge(name string, help string, f func() float64) error {
+	return registry.Register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      name,
+		Help:      help,
+	}, f))
+}
+
+// ObserveStore records the latency of a store operation started at `start`.
+// Use with defer: `defer metrics.ObserveStore("Get", time.Now(), &err)`
+func ObserveStore(operation string, start time.Time, err *error) {
+	result := "success"
+	if err != nil && *err != nil {
+		result = "error"
+	}
+	storeDuration.WithLabelValues(operation, result).Observe(time.Since(start).Seconds())
+}
+
+// ObserveOutbound counts an outbound request. status 0 means the request failed.
+func ObserveOutbound(host string, status int) {
+	s := "error"
+	if status != 0 {
+		s = strconv.Itoa(status)
+	}
+	outboundRequests.WithLabelValues(host, s).Inc()
+}
+
+// Middleware records per-route HTTP request latencies.
+func Middleware(skipper func(c echo.Context) bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if skipper != nil && skipper(c) {
+				return next(c)
+			}
+
+			start := time.Now()
+			err := next(c)
+
+			status := c.Response().Status
+			if err != nil {
+				if he, ok := err.(*echo.HTTPError); ok {
+					status = he.Code
+				} else if !c.Response().Committed {
+					status = 500
+				}
+			}
+			httpRequestDuration.WithLabelValues(c.Request().Method, c.Path(), strconv.Itoa(status)).Observe(time.Since(start).Seconds())
+			return err
+		}
+	}
+}
+
+// Handler serves metrics in Prometheus text format.
+func Handler() echo.HandlerFunc {
+	return echo.WrapHandler(promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+}
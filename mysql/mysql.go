@@ -4,20 +4,54 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"sync"
 
 	_ "github.com/go-sql-driver/mysql"
 )
 
 var dsn string
 
+var (
+	db   *sql.DB
+	dbMu sync.Mutex
+)
+
 func SetDSNTCP(user string, password string, host string, port int, db string) string {
-	dsn = fmt.Sprintf("%s:%s@tcp(%s:%d)/%s", user, password, host, port, db)
-	return fmt.Sprintf("%s:********@tcp(%s:%d)/%s", user, host, port, db)
+	// DATETIME columns (including CURRENT_TIMESTAMP defaults) are in UTC regardless of the server `TZ`
+	params := "loc=UTC&time_zone=%27%2B00%3A00%27"
+	dsn = fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?%s", user, password, host, port, db, params)
+	return fmt.Sprintf("%s:********@tcp(%s:%d)/%s?%s", user, host, port, db, params)
 }
 
+// Open returns the shared connection pool, opening it on first use.
+// Callers must not close the returned *sql.DB; use Close on shutdown instead.
 func Open() (*sql.DB, error) {
+	dbMu.Lock()
+	defer dbMu.Unlock()
+
+	if db != nil {
+		return db, nil
+	}
 	if dsn == "" {
 		return nil, errors.New("dsn does not set")
 	}
-	return sql.Open("mysql", dsn)
+	d, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return nil, err
+	}
+	db = d
+	return db, nil
+}
+
+// Close closes the shared connection pool.
+func Close() error {
+	dbMu.Lock()
+	defer dbMu.Unlock()
+
+	if db == nil {
+		return nil
+	}
+	err := db.Close()
+	db = nil
+	return err
 }
@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Limiter holds a token bucket per key.
+// Each bucket holds up to `limit` tokens and is refilled at `limit` tokens per `period`.
+type Limiter struct {
+	mu      sync.Mutex
+	limit   uint
+	period  time.Duration
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func NewLimiter(limit uint, period time.Duration) *Limiter {
+	l := &Limiter{
+		limit:   limit,
+		period:  period,
+		buckets: map[string]*bucket{},
+	}
+	go l.sweep()
+	return l
+}
+
+// Allow takes a token from the bucket of key.
+// remaining is the number of tokens left, reset is the time until the bucket is full again.
+// When not allowed, reset is the time until the next token is available.
+func (l *Limiter) Allow(key string) (allowed bool, remaining uint, reset time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.limit), last: now}
+		l.buckets[key] = b
+	}
+	b.refill(now, l.rate(), float64(l.limit))
+
+	if b.tokens < 1 {
+		return false, 0, time.Duration((1 - b.tokens) / l.rate())
+	}
+	b.tokens--
+	return true, uint(math.Floor(b.tokens)), time.Duration((float64(l.limit) - b.tokens) / l.rate())
+}
+
+func (l *Limiter) Limit() uint {
+	return l.limit
+}
+
+// tokens per nanosecond
+func (l *Limiter) rate() float64 {
+	return float64(l.limit) / float64(l.period)
+}
+
+func (b *bucket) refill(now time.Time, rate float64, capacity float64) {
+	b.tokens = math.Min(capacity, b.tokens+float64(now.Sub(b.last))*rate)
+	b.last = now
+}
+
+// Remove full buckets periodically to bound memory
+func (l *Limiter) sweep() {
+	for range time.Tick(l.period) {
+		l.mu.Lock()
+		now := time.Now()
+		for key, b := range l.buckets {
+			b.refill(now, l.rate(), float64(l.limit))
+			if b.tokens >= float64(l.limit) {
+				delete(l.buckets, key)
+			}
+		}
+		l.mu.Unlock()
+	}
+}
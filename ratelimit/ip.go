@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// ParseProxies parses comma-separated CIDRs of trusted proxies.
+func ParseProxies(s string) ([]*net.IPNet, error) {
+	var proxies []*net.IPNet
+	for _, cidr := range strings.Split(s, ",") {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+		proxies = append(proxies, n)
+	}
+	return proxies, nil
+}
+
+// ClientIP returns the address of the peer, or the address it forwarded the request for
+// if the peer is a trusted proxy. `X-Forwarded-For` is read from the right, skipping
+// trusted proxies, so that addresses prepended by the client are never used.
+func ClientIP(r *http.Request, proxies []*net.IPNet) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	if !trusted(ip, proxies) {
+		return ip
+	}
+	forwarded := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		f := strings.TrimSpace(forwarded[i])
+		if f == "" || net.ParseIP(f) == nil {
+			break
+		}
+		ip = f
+		if !trusted(ip, proxies) {
+			break
+		}
+	}
+	return ip
+}
+
+func trusted(ip string, proxies []*net.IPNet) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, p := range proxies {
+		if p.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
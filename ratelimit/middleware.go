@@ -0,0 +1,90 @@
+package ratelimit
+
+import (
+	"flow-sprints/jwt"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	jwtGo "github.com/dgrijalva/jwt-go"
+	"github.com/labstack/echo"
+)
+
+// Middleware limits requests per user (JWT `id`), falling back to the client IP (see ClientIP).
+// Safe methods (GET, HEAD, OPTIONS) take tokens from `read`, the others from `write`.
+// A nil limiter disables limiting for that kind of request.
+func Middleware(read *Limiter, write *Limiter, proxies []*net.IPNet, skipper func(c echo.Context) bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if skipper != nil && skipper(c) {
+				return next(c)
+			}
+
+			l, kind := write, "write"
+			switch c.Request().Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				l, kind = read, "read"
+			}
+			if l == nil {
+				return next(c)
+			}
+
+			if !allow(c, l, kind+":"+key(c, proxies)) {
+				return tooManyRequests(c)
+			}
+			return next(c)
+		}
+	}
+}
+
+// IPMiddleware limits all requests per client IP (see ClientIP).
+// It is registered before authentication so that unauthenticated floods are throttled too.
+func IPMiddleware(l *Limiter, proxies []*net.IPNet, skipper func(c echo.Context) bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if skipper != nil && skipper(c) {
+				return next(c)
+			}
+
+			if !allow(c, l, "ip:"+ClientIP(c.Request(), proxies)) {
+				return tooManyRequests(c)
+			}
+			return next(c)
+		}
+	}
+}
+
+// Take a token and set `RateLimit-*` headers
+func allow(c echo.Context, l *Limiter, key string) bool {
+	allowed, remaining, reset := l.Allow(key)
+	h := c.Response().Header()
+	h.Set("RateLimit-Limit", strconv.FormatUint(uint64(l.Limit()), 10))
+	h.Set("RateLimit-Remaining", strconv.FormatUint(uint64(remaining), 10))
+	h.Set("RateLimit-Reset", seconds(reset))
+	if !allowed {
+		h.Set("Retry-After", seconds(reset))
+	}
+	return allowed
+}
+
+func tooManyRequests(c echo.Context) error {
+	// 429: Too many requests
+	reset := c.Response().Header().Get("Retry-After")
+	return c.JSONPretty(http.StatusTooManyRequests, map[string]string{"message": fmt.Sprintf("rate limit exceeded, retry after %s seconds", reset)}, "	")
+}
+
+func key(c echo.Context, proxies []*net.IPNet) string {
+	if token, ok := c.Get("user").(*jwtGo.Token); ok {
+		if claims, ok := token.Claims.(*jwt.JwtCustumClaims); ok && claims.Id != 0 {
+			return "user:" + strconv.FormatUint(claims.Id, 10)
+		}
+	}
+	return "ip:" + ClientIP(c.Request(), proxies)
+}
+
+func seconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
@@ -0,0 +1,41 @@
+package settings
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// GetProject returns the settings of the project.
+// The caller must check that the requesting user can access the project.
+func GetProject(ctx context.Context, id uint64) (s Settings, err error) {
+	defer metrics.ObserveStore("settings.GetProject", time.Now(), &err)
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	queryStr := "SELECT time_zone FROM project_settings WHERE project_id = ?"
+	ctx, span := tracing.StartSQL(ctx, "SELECT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtOut, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtOut.Close()
+
+	rows, err := stmtOut.QueryContext(ctx, id)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		// Defaults
+		return
+	}
+	err = rows.Scan(&s.TimeZone)
+	return
+}
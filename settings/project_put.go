@@ -0,0 +1,35 @@
+package settings
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// PutProject replaces the settings of the project.
+// The caller must check that the requesting user can access the project.
+func PutProject(ctx context.Context, id uint64, put PutBody) (s Settings, err error) {
+	defer metrics.ObserveStore("settings.PutProject", time.Now(), &err)
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	queryStr := "INSERT INTO project_settings (project_id, time_zone) VALUES (?, ?) ON DUPLICATE KEY UPDATE time_zone = VALUES(time_zone)"
+	ctx, span := tracing.StartSQL(ctx, "INSERT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtIns, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtIns.Close()
+	_, err = stmtIns.ExecContext(ctx, id, put.TimeZone)
+	if err != nil {
+		return
+	}
+
+	s.TimeZone = put.TimeZone
+	return
+}
@@ -0,0 +1,11 @@
+package settings
+
+type Settings struct {
+	// IANA time zone name, e.g. `Asia/Tokyo`
+	TimeZone *string `json:"time_zone"`
+}
+
+type PutBody struct {
+	// null resets to the default
+	TimeZone *string `json:"time_zone" validate:"omitempty,TZ"`
+}
@@ -0,0 +1,40 @@
+package settings
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// GetUser returns the settings of userId.
+func GetUser(ctx context.Context, id uint64) (s Settings, err error) {
+	defer metrics.ObserveStore("settings.GetUser", time.Now(), &err)
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	queryStr := "SELECT time_zone FROM user_settings WHERE user_id = ?"
+	ctx, span := tracing.StartSQL(ctx, "SELECT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtOut, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtOut.Close()
+
+	rows, err := stmtOut.QueryContext(ctx, id)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		// Defaults
+		return
+	}
+	err = rows.Scan(&s.TimeZone)
+	return
+}
@@ -0,0 +1,34 @@
+package settings
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// PutUser replaces the settings of userId.
+func PutUser(ctx context.Context, id uint64, put PutBody) (s Settings, err error) {
+	defer metrics.ObserveStore("settings.PutUser", time.Now(), &err)
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	queryStr := "INSERT INTO user_settings (user_id, time_zone) VALUES (?, ?) ON DUPLICATE KEY UPDATE time_zone = VALUES(time_zone)"
+	ctx, span := tracing.StartSQL(ctx, "INSERT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtIns, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtIns.Close()
+	_, err = stmtIns.ExecContext(ctx, id, put.TimeZone)
+	if err != nil {
+		return
+	}
+
+	s.TimeZone = put.TimeZone
+	return
+}
@@ -0,0 +1,135 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"time"
+)
+
+type Burndown struct {
+	SprintId uint64 `json:"sprint_id"`
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	// Points of the items in the sprint when it started
+	CommittedPoints float64       `json:"committed_points"`
+	Days            []BurndownDay `json:"days"`
+}
+
+type BurndownDay struct {
+	Date string `json:"date"`
+	// Ideal remaining points, from the committed points on `start` down to 0
+	IdealPoints float64 `json:"ideal_points"`
+	// Remaining and completed points and items at the end of the day, omitted for future days
+	RemainingPoints *float64 `json:"remaining_points,omitempty"`
+	RemainingItems  *uint64  `json:"remaining_items,omitempty"`
+	CompletedPoints *float64 `json:"completed_points,omitempty"`
+	CompletedItems  *uint64  `json:"completed_items,omitempty"`
+}
+
+// GetBurndown returns the remaining points and items of the sprint at the end of each day,
+// replayed from the recorded status changes of its items.
+func GetBurndown(ctx context.Context, userId uint64, id uint64) (b Burndown, notFound bool, err error) {
+	defer metrics.ObserveStore("GetBurndown", time.Now(), &err)
+
+	// Check permission
+	s, notFound, err := Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+	// Days in the time zone of the sprint
+	loc := s.location(defaultLocation)
+	start, err := time.ParseInLocation("2006-1-2", s.Start, loc)
+	if err != nil {
+		return
+	}
+	end, err := time.ParseInLocation("2006-1-2", s.End, loc)
+	if err != nil {
+		return
+	}
+
+	events, err := getItemEvents(ctx, []uint64{id})
+	if err != nil {
+		return
+	}
+	b.SprintId = id
+	b.Start = s.Start
+	b.End = s.End
+	b.CommittedPoints = committedPoints(s, events[id])
+
+	now := time.Now()
+	replay := newItemReplay(events[id])
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		d := BurndownDay{Date: day.Format("2006-01-02")}
+		if !day.After(now) {
+			// Apply the events until the end of the day (AddDate keeps midnight across DST)
+			replay.until(day.AddDate(0, 0, 1))
+			points, items := remaining(replay.states)
+			d.RemainingPoints = &points
+			d.RemainingItems = &items
+			donePoints, doneItems := completed(replay.states)
+			d.CompletedPoints = &donePoints
+			d.CompletedItems = &doneItems
+		}
+		b.Days = append(b.Days, d)
+	}
+
+	// Ideal line
+	if len(b.Days) > 1 {
+		for i := range b.Days {
+			b.Days[i].IdealPoints = b.CommittedPoints * float64(len(b.Days)-1-i) / float64(len(b.Days)-1)
+		}
+	}
+	return
+}
+
+// Points committed to the sprint: its scope when it started, or at the end of its first day
+// if it had no items yet, as items are often planned on the first day.
+// Shared by burndown and velocity so that both report the same commitment.
+func committedPoints(s Sprint, events []itemEvent) float64 {
+	replay := newItemReplay(events)
+	replay.until(s.startsAt)
+	if points := scope(replay.states); points != 0 {
+		return points
+	}
+	start, err := time.ParseInLocation("2006-1-2", s.Start, s.location(defaultLocation))
+	if err != nil {
+		return 0
+	}
+	replay.until(start.AddDate(0, 0, 1))
+	return scope(replay.states)
+}
+
+// Remaining and completed points of the states
+func scope(states map[uint64]itemState) float64 {
+	remainingPoints, _ := remaining(states)
+	completedPoints, _ := completed(states)
+	return remainingPoints + completedPoints
+}
+
+// Remaining points and items of the states
+func remaining(states map[uint64]itemState) (points float64, items uint64) {
+	for _, s := range states {
+		if s.status == ItemStatusDone || s.status == itemEventRemoved {
+			continue
+		}
+		items++
+		if s.estimate != nil {
+			points += *s.estimate
+		}
+	}
+	return
+}
+
+// Completed points and items of the states
+func completed(states map[uint64]itemState) (points float64, items uint64) {
+	for _, s := range states {
+		if s.status != ItemStatusDone {
+			continue
+		}
+		items++
+		if s.estimate != nil {
+			points += *s.estimate
+		}
+	}
+	return
+}
@@ -0,0 +1,70 @@
+package sprint
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+)
+
+// Size of the burndown chart
+const (
+	svgWidth   = 640
+	svgHeight  = 320
+	svgPadding = 40
+)
+
+// SVG renders the burndown chart with the ideal line (dashed), the remaining points and the completed points (burn-up).
+func (b Burndown) SVG() []byte {
+	maxPoints := 1.0
+	for _, d := range b.Days {
+		if d.IdealPoints > maxPoints {
+			maxPoints = d.IdealPoints
+		}
+		if d.RemainingPoints != nil && *d.RemainingPoints > maxPoints {
+			maxPoints = *d.RemainingPoints
+		}
+		if d.CompletedPoints != nil && *d.CompletedPoints > maxPoints {
+			maxPoints = *d.CompletedPoints
+		}
+	}
+	x := func(i int) float64 {
+		if len(b.Days) < 2 {
+			return svgPadding
+		}
+		return svgPadding + float64(i)*float64(svgWidth-2*svgPadding)/float64(len(b.Days)-1)
+	}
+	y := func(points float64) float64 {
+		return svgHeight - svgPadding - points*float64(svgHeight-2*svgPadding)/maxPoints
+	}
+
+	var ideal, actual, done bytes.Buffer
+	for i, d := range b.Days {
+		fmt.Fprintf(&ideal, "%.1f,%.1f ", x(i), y(d.IdealPoints))
+		if d.RemainingPoints != nil {
+			fmt.Fprintf(&actual, "%.1f,%.1f ", x(i), y(*d.RemainingPoints))
+		}
+		if d.CompletedPoints != nil {
+			fmt.Fprintf(&done, "%.1f,%.1f ", x(i), y(*d.CompletedPoints))
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`, svgWidth, svgHeight, svgWidth, svgHeight)
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="#fff"/>`, svgWidth, svgHeight)
+	// Axes
+	fmt.Fprintf(&buf, `<polyline points="%d,%d %d,%d %d,%d" fill="none" stroke="#333"/>`, svgPadding, svgPadding, svgPadding, svgHeight-svgPadding, svgWidth-svgPadding, svgHeight-svgPadding)
+	fmt.Fprintf(&buf, `<text x="%d" y="%d" text-anchor="end">%g</text>`, svgPadding-4, svgPadding+4, maxPoints)
+	fmt.Fprintf(&buf, `<text x="%d" y="%d" text-anchor="end">0</text>`, svgPadding-4, svgHeight-svgPadding+4)
+	fmt.Fprintf(&buf, `<text x="%d" y="%d">%s</text>`, svgPadding, svgHeight-svgPadding+16, html.EscapeString(b.Start))
+	fmt.Fprintf(&buf, `<text x="%d" y="%d" text-anchor="end">%s</text>`, svgWidth-svgPadding, svgHeight-svgPadding+16, html.EscapeString(b.End))
+	// Lines
+	fmt.Fprintf(&buf, `<polyline points="%s" fill="none" stroke="#999" stroke-dasharray="4 4"/>`, bytes.TrimSpace(ideal.Bytes()))
+	if actual.Len() > 0 {
+		fmt.Fprintf(&buf, `<polyline points="%s" fill="none" stroke="#1f77b4" stroke-width="2"/>`, bytes.TrimSpace(actual.Bytes()))
+	}
+	if done.Len() > 0 {
+		fmt.Fprintf(&buf, `<polyline points="%s" fill="none" stroke="#2ca02c" stroke-width="2"/>`, bytes.TrimSpace(done.Bytes()))
+	}
+	buf.WriteString(`</svg>`)
+	return buf.Bytes()
+}
@@ -0,0 +1,63 @@
+package sprint
+
+import (
+	"math"
+	"time"
+)
+
+type CapacityPutBody struct {
+	// Percentage of the member's time allocated to the sprint
+	Allocation uint64 `json:"allocation" validate:"required,gte=1,lte=100"`
+	// Working days off during the sprint
+	DaysOff float64 `json:"days_off" validate:"gte=0"`
+}
+
+type CapacityMember struct {
+	UserId     uint64  `json:"user_id"`
+	Allocation uint64  `json:"allocation"`
+	DaysOff    float64 `json:"days_off"`
+	PersonDays float64 `json:"person_days"`
+}
+
+type Capacity struct {
+	SprintId    uint64           `json:"sprint_id"`
+	WorkingDays uint64           `json:"working_days"`
+	Members     []CapacityMember `json:"members"`
+	PersonDays  float64          `json:"person_days"`
+	// Completed points per person-day of the last finished sprints of the project with recorded capacity
+	PointsPerPersonDay *float64 `json:"points_per_person_day,omitempty"`
+	SuggestedPoints    *float64 `json:"suggested_points,omitempty"`
+	CommittedPoints    float64  `json:"committed_points"`
+	CommittedItems     uint64   `json:"committed_items"`
+	// What the committed work was compared with (`suggested_points`), omitted without historical velocity
+	ComparedWith *string  `json:"compared_with,omitempty"`
+	OverCapacity bool     `json:"over_capacity"`
+	Warnings     []string `json:"warnings"`
+}
+
+// Basis of the capacity comparison
+const capacityBySuggestedPoints = "suggested_points"
+
+// Number of weekdays from start to end (inclusive)
+func workingDays(start string, end string) (days uint64, err error) {
+	s, err := time.Parse("2006-1-2", start)
+	if err != nil {
+		return
+	}
+	e, err := time.Parse("2006-1-2", end)
+	if err != nil {
+		return
+	}
+	for d := s; !d.After(e); d = d.AddDate(0, 0, 1) {
+		if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
+			days++
+		}
+	}
+	return
+}
+
+// Person-days of the member in a sprint of workingDays
+func personDays(workingDays uint64, m CapacityMember) float64 {
+	days := math.Max(0, float64(workingDays)-m.DaysOff)
+	return days * float64(m.Allocation) / 100
+}
@@ -0,0 +1,47 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// DeleteCapacity removes the recorded availability of memberId from the sprint.
+// The owner and editors can remove it.
+func DeleteCapacity(ctx context.Context, userId uint64, id uint64, memberId uint64) (notFound bool, forbidden bool, err error) {
+	defer metrics.ObserveStore("DeleteCapacity", time.Now(), &err)
+
+	// Check permission
+	s, notFound, err := Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+	if !canEdit(s.Role) {
+		return false, true, nil
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return false, false, err
+	}
+	queryStr := "DELETE FROM sprint_capacities WHERE sprint_id = ? AND user_id = ?"
+	ctx, span := tracing.StartSQL(ctx, "DELETE", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtIns, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return false, false, err
+	}
+	defer stmtIns.Close()
+	result, err := stmtIns.ExecContext(ctx, id, memberId)
+	if err != nil {
+		return false, false, err
+	}
+	affectedRowCount, err := result.RowsAffected()
+	if err != nil {
+		return false, false, err
+	}
+
+	return affectedRowCount == 0, false, nil
+}
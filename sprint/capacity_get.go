@@ -0,0 +1,138 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// GetCapacity returns the capacity of the sprint in person-days and the suggested points
+// from the completed points per person-day of the last finished sprints of the same project.
+// Without such history, the committed work is not compared with the capacity.
+func GetCapacity(ctx context.Context, userId uint64, id uint64) (c Capacity, notFound bool, err error) {
+	defer metrics.ObserveStore("GetCapacity", time.Now(), &err)
+
+	// Check permission
+	s, notFound, err := Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+
+	c.SprintId = id
+	c.CommittedPoints = s.Points
+	c.CommittedItems = s.ItemCount
+	c.Members = []CapacityMember{}
+	c.Warnings = []string{}
+	c.WorkingDays, err = workingDays(s.Start, s.End)
+	if err != nil {
+		return
+	}
+	members, err := getCapacityMembers(ctx, []uint64{id})
+	if err != nil {
+		return
+	}
+	for _, m := range members[id] {
+		m.PersonDays = personDays(c.WorkingDays, m)
+		c.PersonDays += m.PersonDays
+		c.Members = append(c.Members, m)
+	}
+	if len(c.Members) == 0 {
+		c.Warnings = append(c.Warnings, "no capacity recorded for the sprint")
+	}
+
+	// Historical velocity
+	if s.ProjectId == nil {
+		c.Warnings = append(c.Warnings, "no project to compute the velocity from")
+		return
+	}
+	v, err := GetVelocity(ctx, userId, VelocityQuery{ProjectId: s.ProjectId})
+	if err != nil {
+		return
+	}
+	var pastIds []uint64
+	for _, p := range v.Sprints {
+		if p.Id != id {
+			pastIds = append(pastIds, p.Id)
+		}
+	}
+	pastMembers, err := getCapacityMembers(ctx, pastIds)
+	if err != nil {
+		return
+	}
+	var points, days float64
+	for _, p := range v.Sprints {
+		if len(pastMembers[p.Id]) == 0 {
+			continue
+		}
+		pastWorkingDays, err := workingDays(p.Start, p.End)
+		if err != nil {
+			return Capacity{}, false, err
+		}
+		for _, m := range pastMembers[p.Id] {
+			days += personDays(pastWorkingDays, m)
+		}
+		points += p.CompletedPoints
+	}
+	if days == 0 {
+		c.Warnings = append(c.Warnings, "no finished sprints with recorded capacity in the project")
+		return
+	}
+	factor := points / days
+	suggested := c.PersonDays * factor
+	c.PointsPerPersonDay = &factor
+	c.SuggestedPoints = &suggested
+	by := capacityBySuggestedPoints
+	c.ComparedWith = &by
+	if c.CommittedPoints > suggested {
+		c.OverCapacity = true
+		c.Warnings = append(c.Warnings, fmt.Sprintf("committed points (%g) exceed the suggested capacity (%.1f)", c.CommittedPoints, suggested))
+	}
+	return
+}
+
+// Recorded capacities of the sprints by sprint ID
+func getCapacityMembers(ctx context.Context, ids []uint64) (members map[uint64][]CapacityMember, err error) {
+	members = map[uint64][]CapacityMember{}
+	if len(ids) == 0 {
+		return
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	queryStr := "SELECT sprint_id, user_id, allocation, days_off FROM sprint_capacities WHERE sprint_id IN (?" + strings.Repeat(", ?", len(ids)-1) + ") ORDER BY sprint_id, user_id"
+	queryParams := make([]interface{}, len(ids))
+	for i, id := range ids {
+		queryParams[i] = id
+	}
+	ctx, span := tracing.StartSQL(ctx, "SELECT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtOut, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtOut.Close()
+
+	rows, err := stmtOut.QueryContext(ctx, queryParams...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sprintId uint64
+		m := CapacityMember{}
+		err = rows.Scan(&sprintId, &m.UserId, &m.Allocation, &m.DaysOff)
+		if err != nil {
+			return
+		}
+		members[sprintId] = append(members[sprintId], m)
+	}
+
+	return
+}
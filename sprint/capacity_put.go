@@ -0,0 +1,51 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// PutCapacity records the availability of memberId in the sprint. The owner and editors can record it.
+func PutCapacity(ctx context.Context, userId uint64, id uint64, memberId uint64, put CapacityPutBody) (m CapacityMember, notFound bool, forbidden bool, err error) {
+	defer metrics.ObserveStore("PutCapacity", time.Now(), &err)
+
+	// Check permission
+	s, notFound, err := Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+	if !canEdit(s.Role) {
+		forbidden = true
+		return
+	}
+	days, err := workingDays(s.Start, s.End)
+	if err != nil {
+		return
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	queryStr := "INSERT INTO sprint_capacities (sprint_id, user_id, allocation, days_off) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE allocation = VALUES(allocation), days_off = VALUES(days_off)"
+	ctx, span := tracing.StartSQL(ctx, "INSERT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtIns, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtIns.Close()
+	_, err = stmtIns.ExecContext(ctx, id, memberId, put.Allocation, put.DaysOff)
+	if err != nil {
+		return
+	}
+
+	m.UserId = memberId
+	m.Allocation = put.Allocation
+	m.DaysOff = put.DaysOff
+	m.PersonDays = personDays(days, m)
+	return
+}
@@ -0,0 +1,188 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// Modes of a carry-over
+const (
+	CarryOverMove = "move"
+	CarryOverCopy = "copy"
+)
+
+type CarryOverBody struct {
+	TargetSprintId *uint64 `json:"target_sprint_id" validate:"omitempty,gte=1"`
+	Mode           *string `json:"mode" validate:"omitempty,oneof=move copy"`
+	// Carry over from a sprint which has not ended yet
+	Force bool `json:"force"`
+}
+
+type CarryOverResult struct {
+	SprintId       uint64 `json:"sprint_id"`
+	TargetSprintId uint64 `json:"target_sprint_id"`
+	Mode           string `json:"mode"`
+	Items          []Item `json:"items"`
+}
+
+// CarryOver moves (or copies) all items of the sprint that are not done to the target sprint
+// and records each of them in `sprint_carry_overs`.
+// The target defaults to the next sprint of the same project by `start`.
+// userId must be able to edit both sprints.
+// noTarget is true if the target sprint does not exist or there is no next sprint.
+// sameTarget is true if the target is the sprint itself.
+// notEnded is true if the sprint is still in progress and `force` is not set.
+func CarryOver(ctx context.Context, userId uint64, id uint64, body CarryOverBody) (r CarryOverResult, notFound bool, forbidden bool, noTarget bool, sameTarget bool, notEnded bool, err error) {
+	defer metrics.ObserveStore("CarryOver", time.Now(), &err)
+
+	// Check permission
+	s, notFound, err := Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+	if !canEdit(s.Role) {
+		forbidden = true
+		return
+	}
+	if !body.Force && s.endsAt.After(time.Now()) {
+		notEnded = true
+		return
+	}
+
+	// Resolve target
+	var targetId uint64
+	if body.TargetSprintId != nil {
+		targetId = *body.TargetSprintId
+	} else {
+		targetId, noTarget, err = nextInProject(ctx, userId, s)
+		if err != nil || noTarget {
+			return
+		}
+	}
+	if targetId == id {
+		sameTarget = true
+		return
+	}
+	target, noTarget, err := Get(ctx, userId, targetId)
+	if err != nil || noTarget {
+		return
+	}
+	if !canEdit(target.Role) {
+		forbidden = true
+		return
+	}
+
+	r.SprintId = id
+	r.TargetSprintId = targetId
+	r.Mode = CarryOverMove
+	if body.Mode != nil {
+		r.Mode = *body.Mode
+	}
+	r.Items = []Item{}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	ctx, span := tracing.Start(ctx, "CarryOver")
+	defer func() { tracing.End(span, err) }()
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	rows, err := tracing.Query(ctx, tx, "SELECT id, title, reference, estimate, status, assignee_id FROM sprint_items WHERE sprint_id = ? AND status != ? ORDER BY id FOR UPDATE", id, ItemStatusDone)
+	if err != nil {
+		return
+	}
+	var items []Item
+	for rows.Next() {
+		i := Item{}
+		err = rows.Scan(&i.Id, &i.Title, &i.Reference, &i.Estimate, &i.Status, &i.AssigneeId)
+		if err != nil {
+			rows.Close()
+			return
+		}
+		items = append(items, i)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return
+	}
+
+	for _, i := range items {
+		fromItemId := i.Id
+		if r.Mode == CarryOverCopy {
+			result, err := tracing.Exec(ctx, tx, "INSERT INTO sprint_items (sprint_id, title, reference, estimate, status, assignee_id) VALUES (?, ?, ?, ?, ?, ?)", targetId, i.Title, i.Reference, i.Estimate, i.Status, i.AssigneeId)
+			if err != nil {
+				return CarryOverResult{}, false, false, false, false, false, err
+			}
+			newId, err := result.LastInsertId()
+			if err != nil {
+				return CarryOverResult{}, false, false, false, false, false, err
+			}
+			i.Id = uint64(newId)
+		} else {
+			_, err = tracing.Exec(ctx, tx, "UPDATE sprint_items SET sprint_id = ? WHERE id = ?", targetId, i.Id)
+			if err != nil {
+				return
+			}
+			err = recordItemEvent(ctx, tx, id, i.Id, itemEventRemoved, nil)
+			if err != nil {
+				return
+			}
+		}
+		err = recordItemEvent(ctx, tx, targetId, i.Id, i.Status, i.Estimate)
+		if err != nil {
+			return
+		}
+		_, err = tracing.Exec(ctx, tx, "INSERT INTO sprint_carry_overs (from_sprint_id, to_sprint_id, from_item_id, to_item_id, mode, estimate, user_id) VALUES (?, ?, ?, ?, ?, ?, ?)", id, targetId, fromItemId, i.Id, r.Mode, i.Estimate, userId)
+		if err != nil {
+			return
+		}
+		i.SprintId = targetId
+		r.Items = append(r.Items, i)
+	}
+	if err = tx.Commit(); err != nil {
+		return
+	}
+
+	return
+}
+
+// Returns the ID of the next sprint visible to userId in the project of s, ordered by `start`.
+func nextInProject(ctx context.Context, userId uint64, s Sprint) (id uint64, notFound bool, err error) {
+	if s.ProjectId == nil {
+		return 0, true, nil
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	queryStr := "SELECT s.id FROM sprints s" + accessJoin + " WHERE s.project_id = ? AND s.id != ? AND (s.start > ? OR (s.start = ? AND s.id > ?)) AND" + accessWhere + " ORDER BY s.start, s.id LIMIT 1"
+	ctx, span := tracing.StartSQL(ctx, "SELECT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtOut, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtOut.Close()
+
+	rows, err := stmtOut.QueryContext(ctx, userId, userId, s.ProjectId, s.Id, s.Start, s.Start, s.Id, userId)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		// Not found
+		return 0, true, nil
+	}
+	err = rows.Scan(&id)
+	return
+}
@@ -0,0 +1,62 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+type CarryOverRecord struct {
+	FromSprintId uint64   `json:"from_sprint_id"`
+	ToSprintId   uint64   `json:"to_sprint_id"`
+	FromItemId   uint64   `json:"from_item_id"`
+	ToItemId     uint64   `json:"to_item_id"`
+	Mode         string   `json:"mode"`
+	Estimate     *float64 `json:"estimate,omitempty"`
+	UserId       uint64   `json:"user_id"`
+	CreatedAt    string   `json:"created_at"`
+}
+
+// GetCarryOverList returns the carry-overs from and to the sprint if it is visible to userId.
+func GetCarryOverList(ctx context.Context, userId uint64, id uint64) (records []CarryOverRecord, notFound bool, err error) {
+	defer metrics.ObserveStore("GetCarryOverList", time.Now(), &err)
+
+	// Check permission
+	_, notFound, err = Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	queryStr := "SELECT from_sprint_id, to_sprint_id, from_item_id, to_item_id, mode, estimate, user_id, created_at FROM sprint_carry_overs WHERE from_sprint_id = ? OR to_sprint_id = ? ORDER BY id"
+	ctx, span := tracing.StartSQL(ctx, "SELECT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtOut, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtOut.Close()
+
+	rows, err := stmtOut.QueryContext(ctx, id, id)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		r := CarryOverRecord{}
+		err = rows.Scan(&r.FromSprintId, &r.ToSprintId, &r.FromItemId, &r.ToItemId, &r.Mode, &r.Estimate, &r.UserId, &r.CreatedAt)
+		if err != nil {
+			return
+		}
+		r.CreatedAt = formatDatetime(r.CreatedAt)
+		records = append(records, r)
+	}
+
+	return
+}
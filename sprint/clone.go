@@ -0,0 +1,147 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/team"
+	"flow-sprints/tracing"
+	"time"
+)
+
+type CloneBody struct {
+	Start     string  `json:"start" validate:"required,Y-M-D"`
+	Name      *string `json:"name" validate:"omitempty,gte=1"`
+	ProjectId *uint64 `json:"project_id" validate:"omitempty,gte=1"`
+	Goals     bool    `json:"goals"`
+	Items     bool    `json:"items"`
+}
+
+// Clone creates a copy of the sprint starting at `start`, with `end` shifted to keep the length.
+// Goals and items are copied if requested; copied items start over as todo.
+// The clone belongs to the team of the sprint if userId is a member, otherwise to userId.
+func Clone(ctx context.Context, userId uint64, id uint64, body CloneBody) (p Sprint, notFound bool, err error) {
+	defer metrics.ObserveStore("Clone", time.Now(), &err)
+
+	s, notFound, err := Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+
+	// Shift dates
+	oldStart, err := time.Parse("2006-1-2", s.Start)
+	if err != nil {
+		return
+	}
+	oldEnd, err := time.Parse("2006-1-2", s.End)
+	if err != nil {
+		return
+	}
+	start, err := time.Parse("2006-1-2", body.Start)
+	if err != nil {
+		return
+	}
+	end := start.Add(oldEnd.Sub(oldStart))
+
+	name := s.Name
+	if body.Name != nil {
+		name = *body.Name
+	}
+	projectId := s.ProjectId
+	if body.ProjectId != nil {
+		projectId = body.ProjectId
+	}
+	var goals *string
+	if body.Goals {
+		goals, err = EncodeGoals(s.Goals)
+		if err != nil {
+			return
+		}
+	}
+	startTime, err := timeOfDayColumn(s.StartTime)
+	if err != nil {
+		return
+	}
+	endTime, err := timeOfDayColumn(s.EndTime)
+	if err != nil {
+		return
+	}
+	teamId := s.TeamId
+	if teamId != nil {
+		_, isMember, err := team.MemberRole(ctx, *teamId, userId)
+		if err != nil {
+			return Sprint{}, false, err
+		}
+		if !isMember {
+			teamId = nil
+		}
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	ctx, span := tracing.Start(ctx, "Clone")
+	defer func() { tracing.End(span, err) }()
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	number, err := nextNumber(ctx, tx, projectId)
+	if err != nil {
+		return
+	}
+	result, err := tracing.Exec(ctx, tx, "INSERT INTO sprints (user_id, team_id, name, description, goals, start, end, start_time, end_time, project_id, number) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", userId, teamId, name, s.Description, goals, start.Format("2006-01-02"), end.Format("2006-01-02"), startTime, endTime, projectId, number)
+	if err != nil {
+		return
+	}
+	newId, err := result.LastInsertId()
+	if err != nil {
+		return
+	}
+
+	if body.Items {
+		rows, err := tracing.Query(ctx, tx, "SELECT title, reference, estimate, assignee_id FROM sprint_items WHERE sprint_id = ? ORDER BY id", id)
+		if err != nil {
+			return Sprint{}, false, err
+		}
+		var items []Item
+		for rows.Next() {
+			i := Item{}
+			err = rows.Scan(&i.Title, &i.Reference, &i.Estimate, &i.AssigneeId)
+			if err != nil {
+				rows.Close()
+				return Sprint{}, false, err
+			}
+			items = append(items, i)
+		}
+		rows.Close()
+		if err = rows.Err(); err != nil {
+			return Sprint{}, false, err
+		}
+
+		for _, i := range items {
+			result, err := tracing.Exec(ctx, tx, "INSERT INTO sprint_items (sprint_id, title, reference, estimate, status, assignee_id) VALUES (?, ?, ?, ?, ?, ?)", newId, i.Title, i.Reference, i.Estimate, ItemStatusTodo, i.AssigneeId)
+			if err != nil {
+				return Sprint{}, false, err
+			}
+			itemId, err := result.LastInsertId()
+			if err != nil {
+				return Sprint{}, false, err
+			}
+			err = recordItemEvent(ctx, tx, uint64(newId), uint64(itemId), ItemStatusTodo, i.Estimate)
+			if err != nil {
+				return Sprint{}, false, err
+			}
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return
+	}
+
+	// Read back with the resolved role and time zone
+	p, _, err = Get(ctx, userId, uint64(newId))
+	return
+}
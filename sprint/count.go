@@ -0,0 +1,59 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// CountActive counts sprints of all users in progress now, each evaluated in its own time zone.
+func CountActive(ctx context.Context) (count uint64, err error) {
+	defer metrics.ObserveStore("CountActive", time.Now(), &err)
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+
+	// Candidates: local dates differ from UTC dates by at most one day
+	now := time.Now()
+	utcToday := now.UTC()
+	dayBefore := utcToday.AddDate(0, 0, -1).Format("2006-01-02")
+	dayAfter := utcToday.AddDate(0, 0, 1).Format("2006-01-02")
+
+	queryStr := "SELECT s.start, s.end, s.start_time, s.end_time" + zoneColumn + " FROM sprints s" + zoneJoin + " WHERE s.start <= ? AND s.end >= ?"
+	ctx, span := tracing.StartSQL(ctx, "SELECT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtOut, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtOut.Close()
+
+	rows, err := stmtOut.QueryContext(ctx, dayAfter, dayBefore)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		s := Sprint{}
+		err = rows.Scan(&s.Start, &s.End, &s.StartTime, &s.EndTime, &s.zone)
+		if err != nil {
+			return
+		}
+		s.StartTime = formatTimeOfDay(s.StartTime)
+		s.EndTime = formatTimeOfDay(s.EndTime)
+		err = s.resolveTimes(defaultLocation)
+		if err != nil {
+			return
+		}
+		if !s.startsAt.After(now) && now.Before(s.endsAt) {
+			count++
+		}
+	}
+	err = rows.Err()
+	return
+}
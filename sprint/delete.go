@@ -1,27 +1,57 @@
 package sprint
 
-import "flow-sprints/mysql"
+import (
+	"context"
+	"database/sql"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// Delete deletes the sprint. Only the owner (or team owners and admins) can delete it.
+func Delete(ctx context.Context, userId uint64, id uint64) (notFound bool, forbidden bool, err error) {
+	defer metrics.ObserveStore("Delete", time.Now(), &err)
+
+	// Check permission
+	s, notFound, err := Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+	if s.Role != RoleOwner {
+		return false, true, nil
+	}
 
-func Delete(userId uint64, id uint64) (notFound bool, err error) {
 	db, err := mysql.Open()
 	if err != nil {
-		return false, err
+		return false, false, err
 	}
-	defer db.Close()
-	stmtIns, err := db.Prepare("DELETE FROM sprints WHERE user_id = ? AND id = ?")
+	queryStr := "DELETE FROM sprints WHERE id = ?"
+	ctx, span := tracing.Start(ctx, "Delete")
+	defer func() { tracing.End(span, err) }()
+	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
-		return false, err
+		return false, false, err
+	}
+	defer tx.Rollback()
+
+	// Number to close the gap of
+	var projectId, number *uint64
+	err = tracing.QueryRow(ctx, tx, "SELECT project_id, number FROM sprints WHERE id = ? FOR UPDATE", id).Scan(&projectId, &number)
+	if err == sql.ErrNoRows {
+		return true, false, nil
 	}
-	defer stmtIns.Close()
-	result, err := stmtIns.Exec(userId, id)
 	if err != nil {
-		return false, err
+		return false, false, err
 	}
-	affectedRowCount, err := result.RowsAffected()
+	_, err = tracing.Exec(ctx, tx, queryStr, id)
 	if err != nil {
-		return false, err
+		return false, false, err
 	}
-	notFound = affectedRowCount == 0
-
-	return false, nil
+	err = compactNumbers(ctx, tx, projectId, number)
+	if err != nil {
+		return false, false, err
+	}
+	err = tx.Commit()
+	return false, false, err
 }
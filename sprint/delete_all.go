@@ -1,22 +1,61 @@
 package sprint
 
-import "flow-sprints/mysql"
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// DeleteAll deletes personal sprints of userId. Team sprints are not deleted.
+func DeleteAll(ctx context.Context, userId uint64) (err error) {
+	defer metrics.ObserveStore("DeleteAll", time.Now(), &err)
 
-func DeleteAll(userId uint64) (err error) {
 	db, err := mysql.Open()
 	if err != nil {
 		return
 	}
-	defer db.Close()
-	stmtIns, err := db.Prepare("DELETE FROM sprints WHERE user_id = ?")
+	queryStr := "DELETE FROM sprints WHERE team_id IS NULL AND user_id = ?"
+	ctx, span := tracing.Start(ctx, "DeleteAll")
+	defer func() { tracing.End(span, err) }()
+	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		return
 	}
-	defer stmtIns.Close()
-	_, err = stmtIns.Exec(userId)
+	defer tx.Rollback()
+
+	// Numbers to close the gaps of, highest first so that each gap is still in place when it is closed
+	rows, err := tracing.Query(ctx, tx, "SELECT project_id, number FROM sprints WHERE team_id IS NULL AND user_id = ? AND number IS NOT NULL ORDER BY number DESC FOR UPDATE", userId)
 	if err != nil {
 		return
 	}
+	type gap struct{ projectId, number *uint64 }
+	var gaps []gap
+	for rows.Next() {
+		g := gap{}
+		err = rows.Scan(&g.projectId, &g.number)
+		if err != nil {
+			rows.Close()
+			return
+		}
+		gaps = append(gaps, g)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return
+	}
 
+	_, err = tracing.Exec(ctx, tx, queryStr, userId)
+	if err != nil {
+		return
+	}
+	for _, g := range gaps {
+		err = compactNumbers(ctx, tx, g.projectId, g.number)
+		if err != nil {
+			return
+		}
+	}
+	err = tx.Commit()
 	return
 }
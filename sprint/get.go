@@ -1,23 +1,32 @@
 package sprint
 
 import (
+	"context"
+	"flow-sprints/metrics"
 	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
 )
 
-func Get(userId uint64, id uint64) (s Sprint, notFound bool, err error) {
+// Get returns the sprint if it is visible to userId.
+func Get(ctx context.Context, userId uint64, id uint64) (s Sprint, notFound bool, err error) {
+	defer metrics.ObserveStore("Get", time.Now(), &err)
+
 	db, err := mysql.Open()
 	if err != nil {
 		return Sprint{}, false, err
 	}
-	defer db.Close()
 
-	stmtOut, err := db.Prepare("SELECT name, description, start, end, project_id FROM sprints WHERE user_id = ? AND id = ?")
+	queryStr := "SELECT s.user_id, s.team_id, s.name, s.description, s.goals, s.start, s.end, s.start_time, s.end_time, s.project_id, s.number, tm.role, sh.role" + itemsColumns + zoneColumn + " FROM sprints s" + accessJoin + itemsJoin + zoneJoin + " WHERE s.id = ? AND" + accessWhere
+	ctx, span := tracing.StartSQL(ctx, "SELECT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtOut, err := db.PrepareContext(ctx, queryStr)
 	if err != nil {
 		return Sprint{}, false, err
 	}
 	defer stmtOut.Close()
 
-	rows, err := stmtOut.Query(userId, id)
+	rows, err := stmtOut.QueryContext(ctx, userId, userId, id, userId)
 	if err != nil {
 		return Sprint{}, false, err
 	}
@@ -27,11 +36,23 @@ func Get(userId uint64, id uint64) (s Sprint, notFound bool, err error) {
 		// Not found
 		return Sprint{}, true, nil
 	}
-	err = rows.Scan(&s.Name, &s.Description, &s.Start, &s.End, &s.ProjectId)
+	var teamRole, sharedRole, goals *string
+	err = rows.Scan(&s.UserId, &s.TeamId, &s.Name, &s.Description, &goals, &s.Start, &s.End, &s.StartTime, &s.EndTime, &s.ProjectId, &s.Number, &teamRole, &sharedRole, &s.ItemCount, &s.DoneCount, &s.Points, &s.DonePoints, &s.zone)
+	if err != nil {
+		return Sprint{}, false, err
+	}
+	s.Goals, err = DecodeGoals(goals)
+	if err != nil {
+		return Sprint{}, false, err
+	}
+	s.StartTime = formatTimeOfDay(s.StartTime)
+	s.EndTime = formatTimeOfDay(s.EndTime)
+	err = s.resolveTimes(defaultLocation)
 	if err != nil {
 		return Sprint{}, false, err
 	}
 
 	s.Id = id
+	s.Role = role(userId, s.UserId, s.TeamId, teamRole, sharedRole)
 	return
 }
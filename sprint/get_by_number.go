@@ -0,0 +1,39 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// GetByNumber returns the sprint with the sequence number in the project if it is visible to userId.
+func GetByNumber(ctx context.Context, userId uint64, projectId uint64, number uint64) (s Sprint, notFound bool, err error) {
+	defer metrics.ObserveStore("GetByNumber", time.Now(), &err)
+
+	db, err := mysql.Open()
+	if err != nil {
+		return Sprint{}, false, err
+	}
+
+	ctx, span := tracing.Start(ctx, "GetByNumber")
+	defer func() { tracing.End(span, err) }()
+	rows, err := tracing.Query(ctx, db, "SELECT id FROM sprints WHERE project_id = ? AND number = ?", projectId, number)
+	if err != nil {
+		return Sprint{}, false, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		// Not found
+		return Sprint{}, true, nil
+	}
+	var id uint64
+	err = rows.Scan(&id)
+	if err != nil {
+		return Sprint{}, false, err
+	}
+
+	return Get(ctx, userId, id)
+}
@@ -1,46 +1,71 @@
 package sprint
 
 import (
+	"context"
+	"flow-sprints/metrics"
 	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
 )
 
 type GetListQuery struct {
 	Start     *string `query:"start" validate:"omitempty,Y-M-D"`
 	End       *string `query:"end" validate:"omitempty,Y-M-D"`
 	ProjectId *uint64 `query:"project_id" validate:"omitempty,gte=1"`
+	TeamId    *uint64 `query:"team_id" validate:"omitempty,gte=1"`
+	Ownership *string `query:"ownership" validate:"omitempty,oneof=owned shared team"`
 }
 
-func GetList(userId uint64, q GetListQuery) (sprints []Sprint, err error) {
+func GetList(ctx context.Context, userId uint64, q GetListQuery) (sprints []Sprint, err error) {
+	defer metrics.ObserveStore("GetList", time.Now(), &err)
+
 	// Generate query
-	queryStr := "SELECT id, name, description, start, end, project_id FROM sprints WHERE user_id = ?"
-	queryParams := []interface{}{userId}
+	queryStr := "SELECT s.id, s.user_id, s.team_id, s.name, s.description, s.goals, s.start, s.end, s.start_time, s.end_time, s.project_id, s.number, tm.role, sh.role" + itemsColumns + zoneColumn + " FROM sprints s" + accessJoin + itemsJoin + zoneJoin
+	queryParams := []interface{}{userId, userId}
+	switch {
+	case q.Ownership != nil && *q.Ownership == "owned":
+		queryStr += " WHERE s.team_id IS NULL AND s.user_id = ?"
+		queryParams = append(queryParams, userId)
+	case q.Ownership != nil && *q.Ownership == "shared":
+		queryStr += " WHERE sh.user_id IS NOT NULL"
+	case q.Ownership != nil && *q.Ownership == "team":
+		queryStr += " WHERE tm.user_id IS NOT NULL"
+	default:
+		queryStr += " WHERE" + accessWhere
+		queryParams = append(queryParams, userId)
+	}
+	if q.TeamId != nil {
+		queryStr += " AND s.team_id = ?"
+		queryParams = append(queryParams, q.TeamId)
+	}
 	if q.Start != nil {
-		queryStr += " AND end >= ?"
+		queryStr += " AND s.end >= ?"
 		queryParams = append(queryParams, q.Start)
 	}
 	if q.End != nil {
-		queryStr += " AND start <= ?"
+		queryStr += " AND s.start <= ?"
 		queryParams = append(queryParams, q.End)
 	}
 	if q.ProjectId != nil {
-		queryStr += " AND project_id = ?"
+		queryStr += " AND s.project_id = ?"
 		queryParams = append(queryParams, q.ProjectId)
 	}
-	queryStr += " ORDER BY start, end"
+	queryStr += " ORDER BY s.start, s.end"
 
 	db, err := mysql.Open()
 	if err != nil {
 		return
 	}
-	defer db.Close()
 
-	stmtOut, err := db.Prepare(queryStr)
+	ctx, span := tracing.StartSQL(ctx, "SELECT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtOut, err := db.PrepareContext(ctx, queryStr)
 	if err != nil {
 		return
 	}
 	defer stmtOut.Close()
 
-	rows, err := stmtOut.Query(queryParams...)
+	rows, err := stmtOut.QueryContext(ctx, queryParams...)
 	if err != nil {
 		return
 	}
@@ -48,10 +73,22 @@ func GetList(userId uint64, q GetListQuery) (sprints []Sprint, err error) {
 
 	for rows.Next() {
 		s := Sprint{}
-		err = rows.Scan(&s.Id, &s.Name, &s.Description, &s.Start, &s.End, &s.ProjectId)
+		var teamRole, sharedRole, goals *string
+		err = rows.Scan(&s.Id, &s.UserId, &s.TeamId, &s.Name, &s.Description, &goals, &s.Start, &s.End, &s.StartTime, &s.EndTime, &s.ProjectId, &s.Number, &teamRole, &sharedRole, &s.ItemCount, &s.DoneCount, &s.Points, &s.DonePoints, &s.zone)
+		if err != nil {
+			return
+		}
+		s.Goals, err = DecodeGoals(goals)
+		if err != nil {
+			return
+		}
+		s.StartTime = formatTimeOfDay(s.StartTime)
+		s.EndTime = formatTimeOfDay(s.EndTime)
+		err = s.resolveTimes(defaultLocation)
 		if err != nil {
 			return
 		}
+		s.Role = role(userId, s.UserId, s.TeamId, teamRole, sharedRole)
 		sprints = append(sprints, s)
 	}
 
@@ -0,0 +1,49 @@
+package sprint
+
+import "encoding/json"
+
+// Statuses of a sprint item
+const (
+	ItemStatusTodo       = "todo"
+	ItemStatusInProgress = "in_progress"
+	ItemStatusDone       = "done"
+)
+
+type Item struct {
+	Id         uint64   `json:"id"`
+	SprintId   uint64   `json:"sprint_id"`
+	Title      string   `json:"title"`
+	Reference  *string  `json:"reference,omitempty"`
+	Estimate   *float64 `json:"estimate,omitempty"`
+	Status     string   `json:"status"`
+	AssigneeId *uint64  `json:"assignee_id,omitempty"`
+}
+
+type PatchNullJSONFloat64 struct {
+	Float64 **float64 `validate:"omitempty,gte=0"`
+}
+
+func (p *PatchNullJSONFloat64) UnmarshalJSON(data []byte) error {
+	// If this method was called, the value was set.
+	var valueP *float64 = nil
+	if string(data) == "null" {
+		// key exists and value is null
+		p.Float64 = &valueP
+		return nil
+	}
+
+	var tmp float64
+	tmpP := &tmp
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		// invalid value type
+		return err
+	}
+	// valid value
+	p.Float64 = &tmpP
+	return nil
+}
+
+// Whether the role can change the sprint and its items
+func canEdit(role string) bool {
+	return role == RoleOwner || role == RoleEditor
+}
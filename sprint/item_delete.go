@@ -0,0 +1,49 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// DeleteItem removes the item from the sprint. The owner and editors can delete items.
+func DeleteItem(ctx context.Context, userId uint64, id uint64, itemId uint64) (notFound bool, forbidden bool, err error) {
+	defer metrics.ObserveStore("DeleteItem", time.Now(), &err)
+
+	// Check permission
+	s, notFound, err := Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+	if !canEdit(s.Role) {
+		return false, true, nil
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return false, false, err
+	}
+	queryStr := "DELETE FROM sprint_items WHERE sprint_id = ? AND id = ?"
+	ctx, span := tracing.Start(ctx, "DeleteItem")
+	defer func() { tracing.End(span, err) }()
+	result, err := tracing.Exec(ctx, db, queryStr, id, itemId)
+	if err != nil {
+		return false, false, err
+	}
+	affectedRowCount, err := result.RowsAffected()
+	if err != nil {
+		return false, false, err
+	}
+
+	if affectedRowCount == 0 {
+		return true, false, nil
+	}
+	err = recordItemEvent(ctx, db, id, itemId, itemEventRemoved, nil)
+	if err != nil {
+		return false, false, err
+	}
+
+	return false, false, nil
+}
@@ -0,0 +1,96 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"strings"
+	"time"
+)
+
+// Status of an item event when the item leaves the sprint (moved or deleted)
+const itemEventRemoved = "removed"
+
+// Record the status and estimate of the item in the sprint from now on, for burndown charts
+func recordItemEvent(ctx context.Context, db tracing.DB, sprintId uint64, itemId uint64, status string, estimate *float64) error {
+	_, err := tracing.Exec(ctx, db, "INSERT INTO sprint_item_events (sprint_id, item_id, status, estimate, created_at) VALUES (?, ?, ?, ?, ?)", sprintId, itemId, status, estimate, time.Now().UTC().Format(datetimeLayout))
+	return err
+}
+
+type itemState struct {
+	status   string
+	estimate *float64
+}
+
+type itemEvent struct {
+	itemId uint64
+	state  itemState
+	at     time.Time
+}
+
+// Recorded events of the items of the sprints by sprint ID, oldest first
+func getItemEvents(ctx context.Context, sprintIds []uint64) (events map[uint64][]itemEvent, err error) {
+	events = map[uint64][]itemEvent{}
+	if len(sprintIds) == 0 {
+		return
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	queryStr := "SELECT sprint_id, item_id, status, estimate, created_at FROM sprint_item_events WHERE sprint_id IN (?" + strings.Repeat(", ?", len(sprintIds)-1) + ") ORDER BY created_at, id"
+	queryParams := make([]interface{}, len(sprintIds))
+	for i, id := range sprintIds {
+		queryParams[i] = id
+	}
+	ctx, span := tracing.StartSQL(ctx, "SELECT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtOut, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtOut.Close()
+
+	rows, err := stmtOut.QueryContext(ctx, queryParams...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sprintId uint64
+		var createdAt string
+		e := itemEvent{}
+		err = rows.Scan(&sprintId, &e.itemId, &e.state.status, &e.state.estimate, &createdAt)
+		if err != nil {
+			return
+		}
+		// UTC
+		e.at, err = time.Parse(datetimeLayout, createdAt)
+		if err != nil {
+			return
+		}
+		events[sprintId] = append(events[sprintId], e)
+	}
+	err = rows.Err()
+	return
+}
+
+// Replays the events of a sprint in order, keeping the latest state of each item
+type itemReplay struct {
+	events []itemEvent
+	next   int
+	states map[uint64]itemState
+}
+
+func newItemReplay(events []itemEvent) *itemReplay {
+	return &itemReplay{events: events, states: map[uint64]itemState{}}
+}
+
+// Apply the events before t
+func (r *itemReplay) until(t time.Time) {
+	for ; r.next < len(r.events) && r.events[r.next].at.Before(t); r.next++ {
+		r.states[r.events[r.next].itemId] = r.events[r.next].state
+	}
+}
@@ -0,0 +1,54 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// GetItem returns the item of the sprint if the sprint is visible to userId.
+// role is the role of userId on the sprint.
+func GetItem(ctx context.Context, userId uint64, id uint64, itemId uint64) (i Item, role string, notFound bool, err error) {
+	defer metrics.ObserveStore("GetItem", time.Now(), &err)
+
+	// Check permission
+	s, notFound, err := Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	queryStr := "SELECT title, reference, estimate, status, assignee_id FROM sprint_items WHERE sprint_id = ? AND id = ?"
+	ctx, span := tracing.StartSQL(ctx, "SELECT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtOut, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtOut.Close()
+
+	rows, err := stmtOut.QueryContext(ctx, id, itemId)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		// Not found
+		return Item{}, "", true, nil
+	}
+	err = rows.Scan(&i.Title, &i.Reference, &i.Estimate, &i.Status, &i.AssigneeId)
+	if err != nil {
+		return
+	}
+
+	i.Id = itemId
+	i.SprintId = id
+	role = s.Role
+	return
+}
@@ -0,0 +1,67 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+type ItemGetListQuery struct {
+	Status     *string `query:"status" validate:"omitempty,oneof=todo in_progress done"`
+	AssigneeId *uint64 `query:"assignee_id" validate:"omitempty,gte=1"`
+}
+
+// GetItemList returns the items of the sprint if it is visible to userId.
+func GetItemList(ctx context.Context, userId uint64, id uint64, q ItemGetListQuery) (items []Item, notFound bool, err error) {
+	defer metrics.ObserveStore("GetItemList", time.Now(), &err)
+
+	// Check permission
+	_, notFound, err = Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+
+	// Generate query
+	queryStr := "SELECT id, title, reference, estimate, status, assignee_id FROM sprint_items WHERE sprint_id = ?"
+	queryParams := []interface{}{id}
+	if q.Status != nil {
+		queryStr += " AND status = ?"
+		queryParams = append(queryParams, q.Status)
+	}
+	if q.AssigneeId != nil {
+		queryStr += " AND assignee_id = ?"
+		queryParams = append(queryParams, q.AssigneeId)
+	}
+	queryStr += " ORDER BY id"
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	ctx, span := tracing.StartSQL(ctx, "SELECT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtOut, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtOut.Close()
+
+	rows, err := stmtOut.QueryContext(ctx, queryParams...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		i := Item{SprintId: id}
+		err = rows.Scan(&i.Id, &i.Title, &i.Reference, &i.Estimate, &i.Status, &i.AssigneeId)
+		if err != nil {
+			return
+		}
+		items = append(items, i)
+	}
+
+	return
+}
@@ -0,0 +1,118 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"strings"
+	"time"
+)
+
+type ItemPatchBody struct {
+	Title      *string              `json:"title" validate:"omitempty"`
+	Reference  PatchNullJSONString  `json:"reference" validate:"omitempty"`
+	Estimate   PatchNullJSONFloat64 `json:"estimate" validate:"dive"`
+	Status     *string              `json:"status" validate:"omitempty,oneof=todo in_progress done"`
+	AssigneeId PatchNullJSONUint64  `json:"assignee_id" validate:"dive"`
+	SprintId   *uint64              `json:"sprint_id" validate:"omitempty,gte=1"`
+}
+
+// PatchItem updates the item. The owner and editors can update items.
+// With `sprint_id` the item is moved to another sprint, which userId must also be able to edit.
+// sprintNotFound is true if the destination sprint is not visible to userId.
+func PatchItem(ctx context.Context, userId uint64, id uint64, itemId uint64, new ItemPatchBody) (i Item, notFound bool, forbidden bool, sprintNotFound bool, err error) {
+	defer metrics.ObserveStore("PatchItem", time.Now(), &err)
+
+	// Get old
+	i, role, notFound, err := GetItem(ctx, userId, id, itemId)
+	if err != nil || notFound {
+		return
+	}
+	if !canEdit(role) {
+		forbidden = true
+		return
+	}
+
+	// Generate query
+	queryStr := "UPDATE sprint_items SET"
+	var queryParams []interface{}
+	if new.Title != nil {
+		queryStr += " title = ?,"
+		queryParams = append(queryParams, new.Title)
+		i.Title = *new.Title
+	}
+	if new.Reference.String != nil {
+		queryStr += " reference = ?,"
+		queryParams = append(queryParams, *new.Reference.String)
+		i.Reference = *new.Reference.String
+	}
+	if new.Estimate.Float64 != nil {
+		queryStr += " estimate = ?,"
+		queryParams = append(queryParams, *new.Estimate.Float64)
+		i.Estimate = *new.Estimate.Float64
+	}
+	recordEvent := new.Estimate.Float64 != nil
+	if new.Status != nil {
+		queryStr += " status = ?,"
+		queryParams = append(queryParams, new.Status)
+		recordEvent = recordEvent || *new.Status != i.Status
+		i.Status = *new.Status
+	}
+	if new.AssigneeId.UInt64 != nil {
+		queryStr += " assignee_id = ?,"
+		queryParams = append(queryParams, *new.AssigneeId.UInt64)
+		i.AssigneeId = *new.AssigneeId.UInt64
+	}
+	if new.SprintId != nil && *new.SprintId != id {
+		// Move to another sprint
+		var dest Sprint
+		dest, sprintNotFound, err = Get(ctx, userId, *new.SprintId)
+		if err != nil || sprintNotFound {
+			return
+		}
+		if !canEdit(dest.Role) {
+			forbidden = true
+			return
+		}
+		queryStr += " sprint_id = ?,"
+		queryParams = append(queryParams, new.SprintId)
+		i.SprintId = *new.SprintId
+	}
+	if len(queryParams) == 0 {
+		// Nothing to update
+		return
+	}
+	queryStr = strings.TrimRight(queryStr, ",")
+	queryStr += " WHERE id = ?"
+	queryParams = append(queryParams, itemId)
+
+	// Update row
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	ctx, span := tracing.Start(ctx, "PatchItem")
+	defer func() { tracing.End(span, err) }()
+	_, err = tracing.Exec(ctx, db, queryStr, queryParams...)
+	if err != nil {
+		return
+	}
+
+	// Record for burndown charts
+	if i.SprintId != id {
+		err = recordItemEvent(ctx, db, id, itemId, itemEventRemoved, nil)
+		if err != nil {
+			return
+		}
+		recordEvent = true
+	}
+	if recordEvent {
+		err = recordItemEvent(ctx, db, i.SprintId, itemId, i.Status, i.Estimate)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
@@ -0,0 +1,67 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+type ItemPostBody struct {
+	Title      string   `json:"title" validate:"required"`
+	Reference  *string  `json:"reference" validate:"omitempty,gte=1"`
+	Estimate   *float64 `json:"estimate" validate:"omitempty,gte=0"`
+	Status     *string  `json:"status" validate:"omitempty,oneof=todo in_progress done"`
+	AssigneeId *uint64  `json:"assignee_id" validate:"omitempty,gte=1"`
+}
+
+// PostItem adds an item to the sprint. The owner and editors can add items.
+func PostItem(ctx context.Context, userId uint64, id uint64, post ItemPostBody) (i Item, notFound bool, forbidden bool, err error) {
+	defer metrics.ObserveStore("PostItem", time.Now(), &err)
+
+	// Check permission
+	s, notFound, err := Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+	if !canEdit(s.Role) {
+		forbidden = true
+		return
+	}
+
+	status := ItemStatusTodo
+	if post.Status != nil {
+		status = *post.Status
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	queryStr := "INSERT INTO sprint_items (sprint_id, title, reference, estimate, status, assignee_id) VALUES (?, ?, ?, ?, ?, ?)"
+	ctx, span := tracing.Start(ctx, "PostItem")
+	defer func() { tracing.End(span, err) }()
+	result, err := tracing.Exec(ctx, db, queryStr, id, post.Title, post.Reference, post.Estimate, status, post.AssigneeId)
+	if err != nil {
+		return
+	}
+	itemId, err := result.LastInsertId()
+	if err != nil {
+		return
+	}
+
+	err = recordItemEvent(ctx, db, id, uint64(itemId), status, post.Estimate)
+	if err != nil {
+		return
+	}
+
+	i.Id = uint64(itemId)
+	i.SprintId = id
+	i.Title = post.Title
+	i.Reference = post.Reference
+	i.Estimate = post.Estimate
+	i.Status = status
+	i.AssigneeId = post.AssigneeId
+	return
+}
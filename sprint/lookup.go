@@ -0,0 +1,172 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"sort"
+	"time"
+
+	"github.com/go-playground/validator"
+)
+
+// Sprints to look up relative to now
+const (
+	LookupCurrent  = "current"
+	LookupNext     = "next"
+	LookupPrevious = "previous"
+)
+
+type LookupQuery struct {
+	ProjectId *uint64 `query:"project_id" validate:"omitempty,gte=1"`
+	TimeZone  *string `query:"tz" validate:"omitempty,TZ"`
+}
+
+func TimeZoneValidation(fl validator.FieldLevel) bool {
+	// IANA time zone name
+	_, err := time.LoadLocation(fl.Field().String())
+	return err == nil
+}
+
+// Lookup returns the current, next or previous sprint visible to userId, optionally of a project.
+// Each sprint is evaluated in its own time zone; loc (if not nil) replaces the default time zone
+// of sprints whose project and owner have none.
+//
+// Overlapping sprints are resolved deterministically:
+//   - current: the sprint started most recently, then the one ending first, then the lowest ID
+//   - next: the sprint starting first, then the one ending first, then the lowest ID
+//   - previous: the sprint ended most recently, then the one started most recently, then the highest ID
+func Lookup(ctx context.Context, userId uint64, which string, projectId *uint64, loc *time.Location) (s Sprint, notFound bool, err error) {
+	defer metrics.ObserveStore("Lookup", time.Now(), &err)
+
+	// Candidates: local dates differ from UTC dates by at most one day
+	now := time.Now()
+	utcToday := now.UTC()
+	dayBefore := utcToday.AddDate(0, 0, -1).Format("2006-01-02")
+	dayAfter := utcToday.AddDate(0, 0, 1).Format("2006-01-02")
+	q := GetListQuery{ProjectId: projectId, Start: &dayBefore, End: &dayAfter}
+	switch which {
+	case LookupNext:
+		// Sprints starting after dayAfter have not started in any time zone.
+		// Include the sprints up to two days after the earliest of them,
+		// which may start earlier depending on their time zones and times of day.
+		var first *string
+		first, err = boundDate(ctx, userId, "MIN(s.start)", "s.start > ?", dayAfter, projectId, 2)
+		if err != nil {
+			return
+		}
+		if first != nil {
+			q.End = first
+		}
+	case LookupPrevious:
+		// Sprints ending before dayBefore have ended in any time zone.
+		// Include the sprints from two days before the latest of them.
+		var last *string
+		last, err = boundDate(ctx, userId, "MAX(s.end)", "s.end < ?", dayBefore, projectId, -2)
+		if err != nil {
+			return
+		}
+		if last != nil {
+			q.Start = last
+		}
+	}
+	sprints, err := GetList(ctx, userId, q)
+	if err != nil {
+		return
+	}
+
+	// Evaluate in the time zone of each sprint
+	var matches []Sprint
+	for _, c := range sprints {
+		if loc != nil {
+			err = c.resolveTimes(loc)
+			if err != nil {
+				return
+			}
+		}
+		switch which {
+		case LookupCurrent:
+			if !c.startsAt.After(now) && now.Before(c.endsAt) {
+				matches = append(matches, c)
+			}
+		case LookupNext:
+			if c.startsAt.After(now) {
+				matches = append(matches, c)
+			}
+		case LookupPrevious:
+			if !c.endsAt.After(now) {
+				matches = append(matches, c)
+			}
+		}
+	}
+	if len(matches) == 0 {
+		// Not found
+		return Sprint{}, true, nil
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		switch which {
+		case LookupCurrent:
+			if !a.startsAt.Equal(b.startsAt) {
+				return a.startsAt.After(b.startsAt)
+			}
+			if !a.endsAt.Equal(b.endsAt) {
+				return a.endsAt.Before(b.endsAt)
+			}
+			return a.Id < b.Id
+		case LookupNext:
+			if !a.startsAt.Equal(b.startsAt) {
+				return a.startsAt.Before(b.startsAt)
+			}
+			if !a.endsAt.Equal(b.endsAt) {
+				return a.endsAt.Before(b.endsAt)
+			}
+			return a.Id < b.Id
+		default:
+			if !a.endsAt.Equal(b.endsAt) {
+				return a.endsAt.After(b.endsAt)
+			}
+			if !a.startsAt.Equal(b.startsAt) {
+				return a.startsAt.After(b.startsAt)
+			}
+			return a.Id > b.Id
+		}
+	})
+	return matches[0], false, nil
+}
+
+// Aggregated date of the visible sprints matching where (which takes one param), shifted by days.
+// nil if there are no such sprints.
+func boundDate(ctx context.Context, userId uint64, aggregate string, where string, param string, projectId *uint64, days int) (date *string, err error) {
+	queryStr := "SELECT " + aggregate + " FROM sprints s" + accessJoin + " WHERE " + where + " AND" + accessWhere
+	queryParams := []interface{}{userId, userId, param, userId}
+	if projectId != nil {
+		queryStr += " AND s.project_id = ?"
+		queryParams = append(queryParams, projectId)
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	ctx, span := tracing.StartSQL(ctx, "SELECT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtOut, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtOut.Close()
+
+	var d *string
+	err = stmtOut.QueryRowContext(ctx, queryParams...).Scan(&d)
+	if err != nil || d == nil {
+		return
+	}
+	t, err := time.Parse("2006-1-2", *d)
+	if err != nil {
+		return
+	}
+	shifted := t.AddDate(0, 0, days).Format("2006-01-02")
+	return &shifted, nil
+}
@@ -0,0 +1,44 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+type MoveToTeamBody struct {
+	ProjectId *uint64 `json:"project_id" validate:"omitempty,gte=1"`
+}
+
+// MoveToTeam moves personal sprints of userId (optionally only of a project) to the team.
+// The caller must check that userId is a member of the team.
+func MoveToTeam(ctx context.Context, userId uint64, teamId uint64, body MoveToTeamBody) (count int64, err error) {
+	defer metrics.ObserveStore("MoveToTeam", time.Now(), &err)
+
+	queryStr := "UPDATE sprints SET team_id = ? WHERE team_id IS NULL AND user_id = ?"
+	queryParams := []interface{}{teamId, userId}
+	if body.ProjectId != nil {
+		queryStr += " AND project_id = ?"
+		queryParams = append(queryParams, body.ProjectId)
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	ctx, span := tracing.StartSQL(ctx, "UPDATE", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtIns, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtIns.Close()
+	result, err := stmtIns.ExecContext(ctx, queryParams...)
+	if err != nil {
+		return
+	}
+
+	return result.RowsAffected()
+}
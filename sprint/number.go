@@ -0,0 +1,40 @@
+package sprint
+
+import (
+	"context"
+	"database/sql"
+	"flow-sprints/tracing"
+)
+
+// Sequence numbers of sprints in each project (`number` column).
+// A sprint takes the next number when it is created in or moved into a project.
+// When a sprint is deleted or moved out of a project, the later sprints of the project
+// move up by one, so the numbers stay gap-free.
+
+// Next number in the project (nil without project).
+// Locks the numbers of the project until the transaction ends.
+func nextNumber(ctx context.Context, tx *sql.Tx, projectId *uint64) (*uint64, error) {
+	if projectId == nil {
+		return nil, nil
+	}
+	var max *uint64
+	err := tracing.QueryRow(ctx, tx, "SELECT MAX(number) FROM sprints WHERE project_id = ? FOR UPDATE", projectId).Scan(&max)
+	if err != nil {
+		return nil, err
+	}
+	n := uint64(1)
+	if max != nil {
+		n = *max + 1
+	}
+	return &n, nil
+}
+
+// Close the gap left by the number in the project.
+// Numbers are moved in ascending order to keep them unique during the update.
+func compactNumbers(ctx context.Context, tx *sql.Tx, projectId *uint64, number *uint64) error {
+	if projectId == nil || number == nil {
+		return nil
+	}
+	_, err := tracing.Exec(ctx, tx, "UPDATE sprints SET number = number - 1 WHERE project_id = ? AND number > ? ORDER BY number", projectId, number)
+	return err
+}
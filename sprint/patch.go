@@ -1,8 +1,12 @@
 package sprint
 
 import (
+	"context"
 	"encoding/json"
+	"flow-sprints/metrics"
 	"flow-sprints/mysql"
+	"flow-sprints/team"
+	"flow-sprints/tracing"
 	"strings"
 	"time"
 )
@@ -10,9 +14,13 @@ import (
 type PatchBody struct {
 	Name        *string             `json:"name" validate:"omitempty"`
 	Description PatchNullJSONString `json:"description" validate:"omitempty"`
+	Goals       *[]string           `json:"goals" validate:"omitempty,dive,required"`
 	Start       *string             `json:"start,omitempty" validate:"omitempty,Y-M-D"`
 	End         *string             `json:"end,omitempty" validate:"omitempty,Y-M-D"`
+	StartTime   PatchNullJSONTime   `json:"start_time" validate:"dive"`
+	EndTime     PatchNullJSONTime   `json:"end_time" validate:"dive"`
 	ProjectId   PatchNullJSONUint64 `json:"project_id" validate:"dive"`
+	TeamId      PatchNullJSONUint64 `json:"team_id" validate:"dive"`
 }
 
 type PatchNullJSONString struct {
@@ -63,15 +71,25 @@ func (p *PatchNullJSONUint64) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-func Patch(userId uint64, id uint64, new PatchBody) (s Sprint, notFound bool, startAfterEnd bool, err error) {
+// Patch updates the sprint. The owner and editors can update it.
+// Only the owner can move it to a team (`team_id`), and only its creator back to their personal sprints (`team_id: null`).
+// notTeamMember is true if userId is not a member of the destination team.
+// Moving it to another project closes the gap of its number in the old project and numbers it last in the new one.
+func Patch(ctx context.Context, userId uint64, id uint64, new PatchBody) (s Sprint, notFound bool, forbidden bool, startAfterEnd bool, notTeamMember bool, err error) {
+	defer metrics.ObserveStore("Patch", time.Now(), &err)
+
 	// Get old
-	s, notFound, err = Get(userId, id)
+	s, notFound, err = Get(ctx, userId, id)
 	if err != nil {
 		return
 	}
 	if notFound {
 		return
 	}
+	if s.Role != RoleOwner && s.Role != RoleEditor {
+		forbidden = true
+		return
+	}
 
 	// Generate query
 	queryStr := "UPDATE sprints SET"
@@ -92,6 +110,16 @@ func Patch(userId uint64, id uint64, new PatchBody) (s Sprint, notFound bool, st
 			s.Description = nil
 		}
 	}
+	if new.Goals != nil {
+		var goals *string
+		goals, err = EncodeGoals(*new.Goals)
+		if err != nil {
+			return
+		}
+		queryStr += " goals = ?,"
+		queryParams = append(queryParams, goals)
+		s.Goals = *new.Goals
+	}
 	if new.Start != nil {
 		queryStr += " start = ?,"
 		queryParams = append(queryParams, new.Start)
@@ -102,32 +130,81 @@ func Patch(userId uint64, id uint64, new PatchBody) (s Sprint, notFound bool, st
 		queryParams = append(queryParams, new.End)
 		s.End = *new.End
 	}
+	if new.StartTime.String != nil {
+		var startTime *string
+		startTime, err = timeOfDayColumn(*new.StartTime.String)
+		if err != nil {
+			return
+		}
+		queryStr += " start_time = ?,"
+		queryParams = append(queryParams, startTime)
+		s.StartTime = *new.StartTime.String
+	}
+	if new.EndTime.String != nil {
+		var endTime *string
+		endTime, err = timeOfDayColumn(*new.EndTime.String)
+		if err != nil {
+			return
+		}
+		queryStr += " end_time = ?,"
+		queryParams = append(queryParams, endTime)
+		s.EndTime = *new.EndTime.String
+	}
+	projectChanged := false
 	if new.ProjectId.UInt64 != nil {
-		if *new.ProjectId.UInt64 != nil {
-			queryStr += " project_id = ?"
-			queryParams = append(queryParams, **new.ProjectId.UInt64)
-			s.ProjectId = *new.ProjectId.UInt64
+		newProjectId := *new.ProjectId.UInt64
+		projectChanged = (s.ProjectId == nil) != (newProjectId == nil) || (s.ProjectId != nil && *s.ProjectId != *newProjectId)
+		queryStr += " project_id = ?,"
+		queryParams = append(queryParams, newProjectId)
+		s.ProjectId = newProjectId
+		if projectChanged {
+			// Numbered in the new project below
+			queryStr += " number = NULL,"
+		}
+	}
+	if new.TeamId.UInt64 != nil {
+		if *new.TeamId.UInt64 != nil {
+			// Move to team
+			if s.Role != RoleOwner {
+				forbidden = true
+				return
+			}
+			var teamRole string
+			var isMember bool
+			teamRole, isMember, err = team.MemberRole(ctx, **new.TeamId.UInt64, userId)
+			if err != nil {
+				return
+			}
+			if !isMember {
+				notTeamMember = true
+				return
+			}
+			queryStr += " team_id = ?,"
+			queryParams = append(queryParams, **new.TeamId.UInt64)
+			s.TeamId = *new.TeamId.UInt64
+			if !team.CanManage(teamRole) {
+				s.Role = RoleEditor
+			}
 		} else {
-			queryStr += " project_id = ?"
+			// Move to personal sprints of the creator.
+			// Only the creator can take a sprint out of its team, whatever their role in it.
+			if s.UserId != userId {
+				forbidden = true
+				return
+			}
+			queryStr += " team_id = ?,"
 			queryParams = append(queryParams, nil)
-			s.ProjectId = nil
+			s.TeamId = nil
+			s.Role = RoleOwner
 		}
 	}
 	queryStr = strings.TrimRight(queryStr, ",")
-	queryStr += " WHERE user_id = ? AND id = ?"
-	queryParams = append(queryParams, userId, id)
+	queryStr += " WHERE id = ?"
+	queryParams = append(queryParams, id)
 
 	// Check start/end
-	start, err := time.Parse("2006-1-2", s.Start)
-	if err != nil {
-		return
-	}
-	end, err := time.Parse("2006-1-2", s.End)
-	if err != nil {
-		return
-	}
-	if start.After(end) {
-		startAfterEnd = true
+	startAfterEnd, err = endsBeforeStart(s.Start, s.End, s.StartTime, s.EndTime)
+	if err != nil || startAfterEnd {
 		return
 	}
 
@@ -136,16 +213,46 @@ func Patch(userId uint64, id uint64, new PatchBody) (s Sprint, notFound bool, st
 	if err != nil {
 		return
 	}
-	defer db.Close()
-	stmtIns, err := db.Prepare(queryStr)
+	ctx, span := tracing.Start(ctx, "Patch")
+	defer func() { tracing.End(span, err) }()
+	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		return
 	}
-	defer stmtIns.Close()
-	_, err = stmtIns.Exec(queryParams...)
+	defer tx.Rollback()
+
+	// Number to close the gap of in the old project
+	var oldProjectId, oldNumber *uint64
+	if projectChanged {
+		err = tracing.QueryRow(ctx, tx, "SELECT project_id, number FROM sprints WHERE id = ? FOR UPDATE", id).Scan(&oldProjectId, &oldNumber)
+		if err != nil {
+			return
+		}
+	}
+	_, err = tracing.Exec(ctx, tx, queryStr, queryParams...)
 	if err != nil {
 		return
 	}
+	if projectChanged {
+		err = compactNumbers(ctx, tx, oldProjectId, oldNumber)
+		if err != nil {
+			return
+		}
+		var number *uint64
+		number, err = nextNumber(ctx, tx, s.ProjectId)
+		if err != nil {
+			return
+		}
+		_, err = tracing.Exec(ctx, tx, "UPDATE sprints SET number = ? WHERE id = ?", number, id)
+		if err != nil {
+			return
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return
+	}
 
+	// Read back with the resolved time zone, which depends on the project and owner
+	s, _, err = Get(ctx, userId, id)
 	return
 }
@@ -1,18 +1,28 @@
 package sprint
 
 import (
+	"context"
+	"flow-sprints/metrics"
 	"flow-sprints/mysql"
+	"flow-sprints/team"
+	"flow-sprints/tracing"
 	"time"
 
 	"github.com/go-playground/validator"
 )
 
 type PostBody struct {
-	Name        string  `json:"name" validate:"required"`
-	Description *string `json:"description" validate:"omitempty"`
-	Start       string  `json:"start,omitempty" validate:"required,Y-M-D"`
-	End         string  `json:"end,omitempty" validate:"required,Y-M-D"`
-	ProjectId   *uint64 `json:"project_id" validate:"omitempty,gte=1"`
+	Name        string   `json:"name" validate:"required"`
+	Description *string  `json:"description" validate:"omitempty"`
+	Goals       []string `json:"goals" validate:"omitempty,dive,required"`
+	Start       string   `json:"start,omitempty" validate:"required,Y-M-D"`
+	End         string   `json:"end,omitempty" validate:"required,Y-M-D"`
+	StartTime   *string  `json:"start_time" validate:"omitempty,H:M"`
+	EndTime     *string  `json:"end_time" validate:"omitempty,H:M"`
+	ProjectId   *uint64  `json:"project_id" validate:"omitempty,gte=1"`
+	TeamId      *uint64  `json:"team_id" validate:"omitempty,gte=1"`
+	// Template whose values are used for the fields not given
+	TemplateId *uint64 `json:"template_id" validate:"omitempty,gte=1"`
 }
 
 func DateStrValidation(fl validator.FieldLevel) bool {
@@ -21,50 +31,72 @@ func DateStrValidation(fl validator.FieldLevel) bool {
 	return err == nil
 }
 
-func Post(userId uint64, post PostBody) (p Sprint, startAfterEnd bool, err error) {
+// Post creates a sprint owned by userId, or by the team if `team_id` is given.
+// notTeamMember is true if userId is not a member of the team.
+func Post(ctx context.Context, userId uint64, post PostBody) (p Sprint, startAfterEnd bool, notTeamMember bool, err error) {
+	defer metrics.ObserveStore("Post", time.Now(), &err)
+
 	// Check start/end
-	start, err := time.Parse("2006-1-2", post.Start)
+	startAfterEnd, err = endsBeforeStart(post.Start, post.End, post.StartTime, post.EndTime)
+	if err != nil || startAfterEnd {
+		return
+	}
+	startTime, err := timeOfDayColumn(post.StartTime)
 	if err != nil {
 		return
 	}
-	end, err := time.Parse("2006-1-2", post.End)
+	endTime, err := timeOfDayColumn(post.EndTime)
 	if err != nil {
 		return
 	}
-	if start.After(end) {
-		startAfterEnd = true
+
+	// Check team
+	if post.TeamId != nil {
+		_, isMember, err := team.MemberRole(ctx, *post.TeamId, userId)
+		if err != nil {
+			return Sprint{}, false, false, err
+		}
+		if !isMember {
+			notTeamMember = true
+			return Sprint{}, false, true, nil
+		}
+	}
+
+	goals, err := EncodeGoals(post.Goals)
+	if err != nil {
 		return
 	}
 
-	// Insert DB
+	// Insert DB with the next number in the project
 	db, err := mysql.Open()
 	if err != nil {
 		return
 	}
-	defer db.Close()
-	stmtIns, err := db.Prepare("INSERT INTO sprints (user_id, name, description, start, end, project_id) VALUES (?, ?, ?, ?, ?, ?)")
+	queryStr := "INSERT INTO sprints (user_id, team_id, name, description, goals, start, end, start_time, end_time, project_id, number) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	ctx, span := tracing.Start(ctx, "Post")
+	defer func() { tracing.End(span, err) }()
+	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		return
 	}
-	defer stmtIns.Close()
-	result, err := stmtIns.Exec(userId, post.Name, post.Description, post.Start, post.End, post.ProjectId)
+	defer tx.Rollback()
+	number, err := nextNumber(ctx, tx, post.ProjectId)
 	if err != nil {
 		return
 	}
-	id, err := result.LastInsertId()
+	result, err := tracing.Exec(ctx, tx, queryStr, userId, post.TeamId, post.Name, post.Description, goals, post.Start, post.End, startTime, endTime, post.ProjectId, number)
 	if err != nil {
 		return
 	}
-
-	p.Id = uint64(id)
-	p.Name = post.Name
-	p.Start = post.Start
-	p.End = post.End
-	if post.Description != nil {
-		p.Description = post.Description
+	id, err := result.LastInsertId()
+	if err != nil {
+		return
 	}
-	if post.ProjectId != nil {
-		p.ProjectId = post.ProjectId
+	if err = tx.Commit(); err != nil {
+		return
 	}
+
+	// Read back with the resolved role and time zone
+	p, _, err = Get(ctx, userId, uint64(id))
 	return
 }
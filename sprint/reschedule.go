@@ -0,0 +1,142 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+type RescheduleBody struct {
+	Days    *int    `json:"days" validate:"required_without=Start"`
+	Start   *string `json:"start" validate:"required_without=Days,omitempty,Y-M-D"`
+	Preview bool    `json:"preview"`
+}
+
+type RescheduleResult struct {
+	SprintId uint64             `json:"sprint_id"`
+	Days     int                `json:"days"`
+	Preview  bool               `json:"preview"`
+	Sprints  []RescheduleSprint `json:"sprints"`
+}
+
+type RescheduleSprint struct {
+	Id       uint64 `json:"id"`
+	Name     string `json:"name"`
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	NewStart string `json:"new_start"`
+	NewEnd   string `json:"new_end"`
+}
+
+// Reschedule shifts the sprint and all later sprints of its project (by `start`, then ID) by `days`,
+// or by the days between the `start` of the sprint and the new `start`, so that lengths and gaps are kept.
+// A sprint without a project is shifted alone.
+// Nothing is updated in preview mode. The sprints of other users are shifted too,
+// so userId must be able to edit every sprint of the project from the sprint onward.
+func Reschedule(ctx context.Context, userId uint64, id uint64, body RescheduleBody) (r RescheduleResult, notFound bool, forbidden bool, err error) {
+	defer metrics.ObserveStore("Reschedule", time.Now(), &err)
+
+	s, notFound, err := Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+
+	r.SprintId = id
+	r.Preview = body.Preview
+	r.Sprints = []RescheduleSprint{}
+	if body.Days != nil {
+		r.Days = *body.Days
+	} else {
+		var oldStart, newStart time.Time
+		oldStart, err = time.Parse("2006-1-2", s.Start)
+		if err != nil {
+			return
+		}
+		newStart, err = time.Parse("2006-1-2", *body.Start)
+		if err != nil {
+			return
+		}
+		r.Days = int(newStart.Sub(oldStart).Hours() / 24)
+	}
+
+	queryStr := "SELECT s.id, s.user_id, s.team_id, s.name, s.start, s.end, tm.role, sh.role FROM sprints s" + accessJoin + " WHERE "
+	queryParams := []interface{}{userId, userId}
+	if s.ProjectId != nil {
+		queryStr += "s.project_id = ? AND (s.start > ? OR (s.start = ? AND s.id >= ?))"
+		queryParams = append(queryParams, s.ProjectId, s.Start, s.Start, id)
+	} else {
+		queryStr += "s.id = ?"
+		queryParams = append(queryParams, id)
+	}
+	queryStr += " ORDER BY s.start, s.id FOR UPDATE OF s"
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	ctx, span := tracing.Start(ctx, "Reschedule")
+	defer func() { tracing.End(span, err) }()
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	rows, err := tracing.Query(ctx, tx, queryStr, queryParams...)
+	if err != nil {
+		return
+	}
+	for rows.Next() {
+		rs := RescheduleSprint{}
+		var ownerId uint64
+		var teamId *uint64
+		var teamRole, sharedRole *string
+		err = rows.Scan(&rs.Id, &ownerId, &teamId, &rs.Name, &rs.Start, &rs.End, &teamRole, &sharedRole)
+		if err != nil {
+			rows.Close()
+			return
+		}
+		if !canEdit(role(userId, ownerId, teamId, teamRole, sharedRole)) {
+			rows.Close()
+			return RescheduleResult{}, false, true, nil
+		}
+		rs.NewStart, err = shiftDate(rs.Start, r.Days)
+		if err != nil {
+			rows.Close()
+			return
+		}
+		rs.NewEnd, err = shiftDate(rs.End, r.Days)
+		if err != nil {
+			rows.Close()
+			return
+		}
+		r.Sprints = append(r.Sprints, rs)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return
+	}
+
+	if body.Preview || r.Days == 0 {
+		return
+	}
+	for _, rs := range r.Sprints {
+		_, err = tracing.Exec(ctx, tx, "UPDATE sprints SET start = ?, end = ? WHERE id = ?", rs.NewStart, rs.NewEnd, rs.Id)
+		if err != nil {
+			return
+		}
+	}
+	err = tx.Commit()
+	return
+}
+
+// `yyyy-mm-dd` + days
+func shiftDate(date string, days int) (string, error) {
+	t, err := time.Parse("2006-1-2", date)
+	if err != nil {
+		return "", err
+	}
+	return t.AddDate(0, 0, days).Format("2006-01-02"), nil
+}
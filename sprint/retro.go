@@ -0,0 +1,40 @@
+package sprint
+
+// Categories of retrospective entries
+const (
+	RetroWentWell   = "went_well"
+	RetroToImprove  = "to_improve"
+	RetroActionItem = "action_item"
+)
+
+type RetroEntry struct {
+	Id       uint64 `json:"id"`
+	Category string `json:"category"`
+	Text     string `json:"text"`
+	// Omitted for anonymous entries of other users
+	UserId    *uint64 `json:"user_id,omitempty"`
+	Anonymous bool    `json:"anonymous"`
+	Votes     uint64  `json:"votes"`
+	// Whether the requesting user voted for the entry
+	Voted bool `json:"voted"`
+	// Sprint whose goals the action item was carried into
+	CarriedToSprintId *uint64 `json:"carried_to_sprint_id,omitempty"`
+}
+
+type RetroPostBody struct {
+	Category  string `json:"category" validate:"required,oneof=went_well to_improve action_item"`
+	Text      string `json:"text" validate:"required"`
+	Anonymous bool   `json:"anonymous"`
+}
+
+type RetroPatchBody struct {
+	Category *string `json:"category" validate:"omitempty,oneof=went_well to_improve action_item"`
+	Text     *string `json:"text" validate:"omitempty,gte=1"`
+}
+
+// Hide the author of anonymous entries from other users
+func (e *RetroEntry) hideAuthor(userId uint64) {
+	if e.Anonymous && e.UserId != nil && *e.UserId != userId {
+		e.UserId = nil
+	}
+}
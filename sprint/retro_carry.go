@@ -0,0 +1,134 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+type RetroCarryBody struct {
+	TargetSprintId *uint64 `json:"target_sprint_id" validate:"omitempty,gte=1"`
+}
+
+type RetroCarryResult struct {
+	TargetSprintId uint64   `json:"target_sprint_id"`
+	Carried        uint64   `json:"carried"`
+	Goals          []string `json:"goals"`
+}
+
+// CarryRetroActions appends the action items of the sprint's retrospective that were not carried yet
+// to the goals of the target sprint, by default the next sprint of the same project by `start`.
+// userId must be able to edit both sprints.
+// noTarget is true if the target sprint does not exist or there is no next sprint.
+// sameTarget is true if the target is the sprint itself.
+func CarryRetroActions(ctx context.Context, userId uint64, id uint64, body RetroCarryBody) (r RetroCarryResult, notFound bool, forbidden bool, noTarget bool, sameTarget bool, err error) {
+	defer metrics.ObserveStore("CarryRetroActions", time.Now(), &err)
+
+	// Check permission
+	s, notFound, err := Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+	if !canEdit(s.Role) {
+		forbidden = true
+		return
+	}
+
+	// Resolve target
+	var targetId uint64
+	if body.TargetSprintId != nil {
+		targetId = *body.TargetSprintId
+	} else {
+		targetId, noTarget, err = nextInProject(ctx, userId, s)
+		if err != nil || noTarget {
+			return
+		}
+	}
+	if targetId == id {
+		sameTarget = true
+		return
+	}
+	target, noTarget, err := Get(ctx, userId, targetId)
+	if err != nil || noTarget {
+		return
+	}
+	if !canEdit(target.Role) {
+		forbidden = true
+		return
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	ctx, span := tracing.Start(ctx, "CarryRetroActions")
+	defer func() { tracing.End(span, err) }()
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	// Goals of the target
+	var goalsStr *string
+	err = tracing.QueryRow(ctx, tx, "SELECT goals FROM sprints WHERE id = ? FOR UPDATE", targetId).Scan(&goalsStr)
+	if err != nil {
+		return
+	}
+	goals, err := DecodeGoals(goalsStr)
+	if err != nil {
+		return
+	}
+
+	// Action items not carried yet
+	rows, err := tracing.Query(ctx, tx, "SELECT id, text FROM sprint_retro_entries WHERE sprint_id = ? AND category = ? AND carried_to_sprint_id IS NULL ORDER BY id FOR UPDATE", id, RetroActionItem)
+	if err != nil {
+		return
+	}
+	var entryIds []uint64
+	for rows.Next() {
+		var entryId uint64
+		var text string
+		err = rows.Scan(&entryId, &text)
+		if err != nil {
+			rows.Close()
+			return
+		}
+		entryIds = append(entryIds, entryId)
+		goals = append(goals, text)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return
+	}
+
+	if len(entryIds) > 0 {
+		goalsStr, err = EncodeGoals(goals)
+		if err != nil {
+			return
+		}
+		_, err = tracing.Exec(ctx, tx, "UPDATE sprints SET goals = ? WHERE id = ?", goalsStr, targetId)
+		if err != nil {
+			return
+		}
+		for _, entryId := range entryIds {
+			_, err = tracing.Exec(ctx, tx, "UPDATE sprint_retro_entries SET carried_to_sprint_id = ? WHERE id = ?", targetId, entryId)
+			if err != nil {
+				return
+			}
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return
+	}
+
+	r.TargetSprintId = targetId
+	r.Carried = uint64(len(entryIds))
+	r.Goals = goals
+	if r.Goals == nil {
+		r.Goals = []string{}
+	}
+	return
+}
@@ -0,0 +1,46 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// DeleteRetro deletes the retrospective entry. The author and the owner of the sprint can delete it.
+func DeleteRetro(ctx context.Context, userId uint64, id uint64, entryId uint64) (notFound bool, forbidden bool, err error) {
+	defer metrics.ObserveStore("DeleteRetro", time.Now(), &err)
+
+	// Check permission
+	e, role, notFound, err := GetRetro(ctx, userId, id, entryId)
+	if err != nil || notFound {
+		return
+	}
+	if *e.UserId != userId && role != RoleOwner {
+		return false, true, nil
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return false, false, err
+	}
+	queryStr := "DELETE FROM sprint_retro_entries WHERE id = ?"
+	ctx, span := tracing.StartSQL(ctx, "DELETE", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtIns, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return false, false, err
+	}
+	defer stmtIns.Close()
+	result, err := stmtIns.ExecContext(ctx, entryId)
+	if err != nil {
+		return false, false, err
+	}
+	affectedRowCount, err := result.RowsAffected()
+	if err != nil {
+		return false, false, err
+	}
+
+	return affectedRowCount == 0, false, nil
+}
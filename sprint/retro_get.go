@@ -0,0 +1,52 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// GetRetro returns the retrospective entry of the sprint with its author, and the role of userId on the sprint.
+// The caller must hide the author of anonymous entries.
+func GetRetro(ctx context.Context, userId uint64, id uint64, entryId uint64) (e RetroEntry, role string, notFound bool, err error) {
+	defer metrics.ObserveStore("GetRetro", time.Now(), &err)
+
+	// Check permission
+	s, notFound, err := Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	queryStr := "SELECT " + retroColumns + " FROM sprint_retro_entries e WHERE e.sprint_id = ? AND e.id = ?"
+	ctx, span := tracing.StartSQL(ctx, "SELECT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtOut, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtOut.Close()
+
+	rows, err := stmtOut.QueryContext(ctx, userId, id, entryId)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		// Not found
+		return RetroEntry{}, "", true, nil
+	}
+	e, err = scanRetroEntry(rows)
+	if err != nil {
+		return
+	}
+
+	role = s.Role
+	return
+}
@@ -0,0 +1,64 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// Columns of a retrospective entry with its votes; takes the requesting user ID once.
+const retroColumns = "e.id, e.category, e.text, e.user_id, e.anonymous, e.carried_to_sprint_id, (SELECT COUNT(*) FROM sprint_retro_votes v WHERE v.entry_id = e.id) AS votes, EXISTS (SELECT 1 FROM sprint_retro_votes v WHERE v.entry_id = e.id AND v.user_id = ?) AS voted"
+
+// GetRetroList returns the retrospective entries of the sprint by category, most voted first.
+func GetRetroList(ctx context.Context, userId uint64, id uint64) (entries []RetroEntry, notFound bool, err error) {
+	defer metrics.ObserveStore("GetRetroList", time.Now(), &err)
+
+	// Check permission
+	_, notFound, err = Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	queryStr := "SELECT " + retroColumns + " FROM sprint_retro_entries e WHERE e.sprint_id = ? ORDER BY FIELD(e.category, 'went_well', 'to_improve', 'action_item'), votes DESC, e.id"
+	ctx, span := tracing.StartSQL(ctx, "SELECT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtOut, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtOut.Close()
+
+	rows, err := stmtOut.QueryContext(ctx, userId, id)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		e, err := scanRetroEntry(rows)
+		if err != nil {
+			return nil, false, err
+		}
+		e.hideAuthor(userId)
+		entries = append(entries, e)
+	}
+
+	return
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRetroEntry(row scanner) (e RetroEntry, err error) {
+	var authorId uint64
+	err = row.Scan(&e.Id, &e.Category, &e.Text, &authorId, &e.Anonymous, &e.CarriedToSprintId, &e.Votes, &e.Voted)
+	e.UserId = &authorId
+	return
+}
@@ -0,0 +1,65 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"strings"
+	"time"
+)
+
+// PatchRetro updates the retrospective entry. The author and the owner of the sprint can update it.
+func PatchRetro(ctx context.Context, userId uint64, id uint64, entryId uint64, new RetroPatchBody) (e RetroEntry, notFound bool, forbidden bool, err error) {
+	defer metrics.ObserveStore("PatchRetro", time.Now(), &err)
+
+	// Get old
+	e, role, notFound, err := GetRetro(ctx, userId, id, entryId)
+	if err != nil || notFound {
+		return
+	}
+	if *e.UserId != userId && role != RoleOwner {
+		return RetroEntry{}, false, true, nil
+	}
+	e.hideAuthor(userId)
+
+	// Generate query
+	queryStr := "UPDATE sprint_retro_entries SET"
+	var queryParams []interface{}
+	if new.Category != nil {
+		queryStr += " category = ?,"
+		queryParams = append(queryParams, new.Category)
+		e.Category = *new.Category
+	}
+	if new.Text != nil {
+		queryStr += " text = ?,"
+		queryParams = append(queryParams, new.Text)
+		e.Text = *new.Text
+	}
+	if len(queryParams) == 0 {
+		// Nothing to update
+		return
+	}
+	queryStr = strings.TrimRight(queryStr, ",")
+	queryStr += " WHERE id = ?"
+	queryParams = append(queryParams, entryId)
+
+	// Update row
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	ctx, span := tracing.StartSQL(ctx, "UPDATE", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtIns, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtIns.Close()
+	_, err = stmtIns.ExecContext(ctx, queryParams...)
+	if err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,48 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// PostRetro adds a retrospective entry to the sprint. Every user who can view the sprint can add entries.
+func PostRetro(ctx context.Context, userId uint64, id uint64, post RetroPostBody) (e RetroEntry, notFound bool, err error) {
+	defer metrics.ObserveStore("PostRetro", time.Now(), &err)
+
+	// Check permission
+	_, notFound, err = Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	queryStr := "INSERT INTO sprint_retro_entries (sprint_id, user_id, category, text, anonymous) VALUES (?, ?, ?, ?, ?)"
+	ctx, span := tracing.StartSQL(ctx, "INSERT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtIns, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtIns.Close()
+	result, err := stmtIns.ExecContext(ctx, id, userId, post.Category, post.Text, post.Anonymous)
+	if err != nil {
+		return
+	}
+	entryId, err := result.LastInsertId()
+	if err != nil {
+		return
+	}
+
+	e.Id = uint64(entryId)
+	e.Category = post.Category
+	e.Text = post.Text
+	e.UserId = &userId
+	e.Anonymous = post.Anonymous
+	return
+}
@@ -0,0 +1,60 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// VoteRetro adds (or with remove, withdraws) the vote of userId for the retrospective entry.
+// Every user who can view the sprint can vote once per entry.
+func VoteRetro(ctx context.Context, userId uint64, id uint64, entryId uint64, remove bool) (e RetroEntry, notFound bool, err error) {
+	defer metrics.ObserveStore("VoteRetro", time.Now(), &err)
+
+	// Check permission
+	e, _, notFound, err = GetRetro(ctx, userId, id, entryId)
+	if err != nil || notFound {
+		return
+	}
+	e.hideAuthor(userId)
+	if e.Voted != remove {
+		// Nothing to change
+		return
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	queryStr := "INSERT IGNORE INTO sprint_retro_votes (entry_id, user_id) VALUES (?, ?)"
+	operation := "INSERT"
+	if remove {
+		queryStr = "DELETE FROM sprint_retro_votes WHERE entry_id = ? AND user_id = ?"
+		operation = "DELETE"
+	}
+	ctx, span := tracing.StartSQL(ctx, operation, queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtIns, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtIns.Close()
+	result, err := stmtIns.ExecContext(ctx, entryId, userId)
+	if err != nil {
+		return
+	}
+	affectedRowCount, err := result.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	if remove {
+		e.Votes -= uint64(affectedRowCount)
+	} else {
+		e.Votes += uint64(affectedRowCount)
+	}
+	e.Voted = !remove
+	return
+}
@@ -0,0 +1,10 @@
+package sprint
+
+type Share struct {
+	UserId uint64 `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+type SharePutBody struct {
+	Role string `json:"role" validate:"required,oneof=viewer editor"`
+}
@@ -0,0 +1,47 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// DeleteShare stops sharing the sprint with shareUserId.
+// The owner can remove anyone, other users can only remove themselves.
+func DeleteShare(ctx context.Context, userId uint64, id uint64, shareUserId uint64) (notFound bool, forbidden bool, err error) {
+	defer metrics.ObserveStore("DeleteShare", time.Now(), &err)
+
+	// Check permission
+	s, notFound, err := Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+	if s.Role != RoleOwner && userId != shareUserId {
+		return false, true, nil
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return false, false, err
+	}
+	queryStr := "DELETE FROM sprint_shares WHERE sprint_id = ? AND user_id = ?"
+	ctx, span := tracing.StartSQL(ctx, "DELETE", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtIns, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return false, false, err
+	}
+	defer stmtIns.Close()
+	result, err := stmtIns.ExecContext(ctx, id, shareUserId)
+	if err != nil {
+		return false, false, err
+	}
+	affectedRowCount, err := result.RowsAffected()
+	if err != nil {
+		return false, false, err
+	}
+
+	return affectedRowCount == 0, false, nil
+}
@@ -0,0 +1,51 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// GetShareList returns users the sprint is shared with.
+func GetShareList(ctx context.Context, userId uint64, id uint64) (shares []Share, notFound bool, err error) {
+	defer metrics.ObserveStore("GetShareList", time.Now(), &err)
+
+	// Check permission
+	_, notFound, err = Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+
+	queryStr := "SELECT user_id, role FROM sprint_shares WHERE sprint_id = ? ORDER BY user_id"
+	ctx, span := tracing.StartSQL(ctx, "SELECT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtOut, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtOut.Close()
+
+	rows, err := stmtOut.QueryContext(ctx, id)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		s := Share{}
+		err = rows.Scan(&s.UserId, &s.Role)
+		if err != nil {
+			return
+		}
+		shares = append(shares, s)
+	}
+
+	return
+}
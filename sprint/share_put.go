@@ -0,0 +1,46 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// PutShare shares the sprint with shareUserId, or changes the role if already shared.
+// Only the owner can share the sprint.
+func PutShare(ctx context.Context, userId uint64, id uint64, shareUserId uint64, put SharePutBody) (s Share, notFound bool, forbidden bool, err error) {
+	defer metrics.ObserveStore("PutShare", time.Now(), &err)
+
+	// Check permission
+	sprint, notFound, err := Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+	if sprint.Role != RoleOwner {
+		forbidden = true
+		return
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	queryStr := "INSERT INTO sprint_shares (sprint_id, user_id, role) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE role = VALUES(role)"
+	ctx, span := tracing.StartSQL(ctx, "INSERT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtIns, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtIns.Close()
+	_, err = stmtIns.ExecContext(ctx, id, shareUserId, put.Role)
+	if err != nil {
+		return
+	}
+
+	s.UserId = shareUserId
+	s.Role = put.Role
+	return
+}
@@ -1,10 +1,106 @@
 package sprint
 
+import (
+	"encoding/json"
+	"flow-sprints/team"
+	"time"
+)
+
+// Roles of the requesting user on a sprint
+const (
+	RoleOwner  = "owner"
+	RoleEditor = "editor"
+	RoleViewer = "viewer"
+)
+
+// Layout of DATETIME columns
+const datetimeLayout = "2006-01-02 15:04:05"
+
 type Sprint struct {
-	Id          uint64  `json:"id"`
-	Name        string  `json:"name"`
-	Description *string `json:"description,omitempty"`
-	Start       string  `json:"start"`
-	End         string  `json:"end"`
-	ProjectId   *uint64 `json:"project_id,omitempty"`
+	Id          uint64   `json:"id"`
+	UserId      uint64   `json:"user_id"`
+	TeamId      *uint64  `json:"team_id,omitempty"`
+	Role        string   `json:"role"`
+	Name        string   `json:"name"`
+	Description *string  `json:"description,omitempty"`
+	Goals       []string `json:"goals,omitempty"`
+	Start       string   `json:"start"`
+	End         string   `json:"end"`
+	StartTime   *string  `json:"start_time,omitempty"`
+	EndTime     *string  `json:"end_time,omitempty"`
+	TimeZone    string   `json:"time_zone"`
+	StartsAt    string   `json:"starts_at"`
+	EndsAt      string   `json:"ends_at"`
+	ProjectId   *uint64  `json:"project_id,omitempty"`
+	Number      *uint64  `json:"number,omitempty"`
+	ItemCount   uint64   `json:"item_count"`
+	DoneCount   uint64   `json:"done_count"`
+	Points      float64  `json:"points"`
+	DonePoints  float64  `json:"done_points"`
+
+	// Time zone of the project or owner (NULL if none) and the resolved boundaries
+	zone     *string
+	startsAt time.Time
+	endsAt   time.Time
+}
+
+// Joins resolving the team role and the shared role of the requesting user.
+// Takes the user ID twice.
+const accessJoin = " LEFT JOIN team_members tm ON tm.team_id = s.team_id AND tm.user_id = ? LEFT JOIN sprint_shares sh ON sh.sprint_id = s.id AND sh.user_id = ?"
+
+// Join and columns of the item counts and point totals of each sprint.
+// The lateral derived table only reads the items of the sprints being selected.
+const itemsJoin = " LEFT JOIN LATERAL (SELECT COUNT(*) AS item_count, SUM(status = 'done') AS done_count, SUM(estimate) AS points, SUM(IF(status = 'done', estimate, 0)) AS done_points FROM sprint_items WHERE sprint_id = s.id) it ON TRUE"
+const itemsColumns = ", COALESCE(it.item_count, 0), COALESCE(it.done_count, 0), COALESCE(it.points, 0), COALESCE(it.done_points, 0)"
+
+// Condition of sprints visible to the requesting user:
+// personal sprints they own, sprints of their teams and sprints shared with them.
+// Takes the user ID once.
+const accessWhere = " ((s.team_id IS NULL AND s.user_id = ?) OR tm.user_id IS NOT NULL OR sh.user_id IS NOT NULL)"
+
+// Resolve the role of userId from the owner, the team role and the shared role (NULL if none)
+func role(userId uint64, ownerId uint64, teamId *uint64, teamRole *string, sharedRole *string) string {
+	if teamId == nil && ownerId == userId {
+		return RoleOwner
+	}
+	if teamRole != nil {
+		if team.CanManage(*teamRole) {
+			return RoleOwner
+		}
+		return RoleEditor
+	}
+	if sharedRole != nil {
+		return *sharedRole
+	}
+	return ""
+}
+
+// DATETIME (UTC) -> RFC3339
+func formatDatetime(s string) string {
+	t, err := time.Parse(datetimeLayout, s)
+	if err != nil {
+		return s
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// EncodeGoals and DecodeGoals convert goals from/to `goals` columns (JSON array, NULL if none).
+func EncodeGoals(goals []string) (*string, error) {
+	if len(goals) == 0 {
+		return nil, nil
+	}
+	b, err := json.Marshal(goals)
+	if err != nil {
+		return nil, err
+	}
+	s := string(b)
+	return &s, nil
+}
+
+func DecodeGoals(s *string) (goals []string, err error) {
+	if s == nil {
+		return nil, nil
+	}
+	err = json.Unmarshal([]byte(*s), &goals)
+	return
 }
@@ -0,0 +1,45 @@
+package sprint
+
+import "time"
+
+type Standup struct {
+	UserId    uint64  `json:"user_id"`
+	Date      string  `json:"date"`
+	Text      string  `json:"text"`
+	Blockers  *string `json:"blockers,omitempty"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+type StandupPostBody struct {
+	// Default is today
+	Date     *string `json:"date" validate:"omitempty,Y-M-D"`
+	Text     string  `json:"text" validate:"required"`
+	Blockers *string `json:"blockers" validate:"omitempty,gte=1"`
+}
+
+type StandupQuery struct {
+	Date *string `query:"date" validate:"omitempty,Y-M-D"`
+}
+
+type StandupDigest struct {
+	Date    string   `json:"date"`
+	Posted  []uint64 `json:"posted"`
+	Missing []uint64 `json:"missing"`
+}
+
+// Normalize `yyyy-m-d` to `yyyy-mm-dd` and check that it is within the sprint
+func withinSprint(s Sprint, date string) (normalized string, ok bool, err error) {
+	d, err := time.Parse("2006-1-2", date)
+	if err != nil {
+		return
+	}
+	start, err := time.Parse("2006-1-2", s.Start)
+	if err != nil {
+		return
+	}
+	end, err := time.Parse("2006-1-2", s.End)
+	if err != nil {
+		return
+	}
+	return d.Format("2006-01-02"), !d.Before(start) && !d.After(end), nil
+}
@@ -0,0 +1,43 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// DeleteStandup deletes the stand-up entry of userId for the day.
+func DeleteStandup(ctx context.Context, userId uint64, id uint64, date string) (notFound bool, err error) {
+	defer metrics.ObserveStore("DeleteStandup", time.Now(), &err)
+
+	// Check permission
+	_, notFound, err = Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return false, err
+	}
+	queryStr := "DELETE FROM sprint_standups WHERE sprint_id = ? AND user_id = ? AND date = ?"
+	ctx, span := tracing.StartSQL(ctx, "DELETE", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtIns, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return false, err
+	}
+	defer stmtIns.Close()
+	result, err := stmtIns.ExecContext(ctx, id, userId, date)
+	if err != nil {
+		return false, err
+	}
+	affectedRowCount, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affectedRowCount == 0, nil
+}
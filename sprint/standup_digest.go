@@ -0,0 +1,82 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// Participants of a sprint: the owner of a personal sprint, the members of its team,
+// the users it is shared with and the members with recorded capacity. Takes the sprint ID four times.
+const participantsQuery = "SELECT user_id FROM sprints WHERE id = ? AND team_id IS NULL" +
+	" UNION SELECT tm.user_id FROM sprints s JOIN team_members tm ON tm.team_id = s.team_id WHERE s.id = ?" +
+	" UNION SELECT user_id FROM sprint_shares WHERE sprint_id = ?" +
+	" UNION SELECT user_id FROM sprint_capacities WHERE sprint_id = ?"
+
+// GetStandupDigest returns which participants of the sprint have and haven't posted a stand-up entry for the day.
+// Users who posted without being participants are included in posted.
+func GetStandupDigest(ctx context.Context, userId uint64, id uint64, q StandupQuery) (d StandupDigest, notFound bool, outOfRange bool, err error) {
+	defer metrics.ObserveStore("GetStandupDigest", time.Now(), &err)
+
+	// Check permission
+	s, notFound, err := Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+
+	// Check date
+	// Today in the time zone of the sprint
+	date := today(s.location(defaultLocation))
+	if q.Date != nil {
+		date = *q.Date
+	}
+	date, ok, err := withinSprint(s, date)
+	if err != nil {
+		return
+	}
+	if !ok {
+		outOfRange = true
+		return
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	queryStr := "SELECT p.user_id, st.user_id IS NOT NULL FROM (" + participantsQuery + " UNION SELECT user_id FROM sprint_standups WHERE sprint_id = ? AND date = ?) p" +
+		" LEFT JOIN sprint_standups st ON st.sprint_id = ? AND st.date = ? AND st.user_id = p.user_id ORDER BY p.user_id"
+	ctx, span := tracing.StartSQL(ctx, "SELECT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtOut, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtOut.Close()
+
+	rows, err := stmtOut.QueryContext(ctx, id, id, id, id, id, date, id, date)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	d.Date = date
+	d.Posted = []uint64{}
+	d.Missing = []uint64{}
+	for rows.Next() {
+		var participantId uint64
+		var posted bool
+		err = rows.Scan(&participantId, &posted)
+		if err != nil {
+			return
+		}
+		if posted {
+			d.Posted = append(d.Posted, participantId)
+		} else {
+			d.Missing = append(d.Missing, participantId)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,59 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// GetStandupList returns the stand-up entries of the sprint, optionally of one day.
+func GetStandupList(ctx context.Context, userId uint64, id uint64, q StandupQuery) (standups []Standup, notFound bool, err error) {
+	defer metrics.ObserveStore("GetStandupList", time.Now(), &err)
+
+	// Check permission
+	_, notFound, err = Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+
+	// Generate query
+	queryStr := "SELECT user_id, date, text, blockers, updated_at FROM sprint_standups WHERE sprint_id = ?"
+	queryParams := []interface{}{id}
+	if q.Date != nil {
+		queryStr += " AND date = ?"
+		queryParams = append(queryParams, q.Date)
+	}
+	queryStr += " ORDER BY date, user_id"
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	ctx, span := tracing.StartSQL(ctx, "SELECT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtOut, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtOut.Close()
+
+	rows, err := stmtOut.QueryContext(ctx, queryParams...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		st := Standup{}
+		err = rows.Scan(&st.UserId, &st.Date, &st.Text, &st.Blockers, &st.UpdatedAt)
+		if err != nil {
+			return
+		}
+		st.UpdatedAt = formatDatetime(st.UpdatedAt)
+		standups = append(standups, st)
+	}
+
+	return
+}
@@ -0,0 +1,62 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// PostStandup records the stand-up entry of userId for the day, replacing an earlier one of the same day.
+// Every user who can view the sprint can post.
+// outOfRange is true if the date is not within `start`..`end` of the sprint.
+func PostStandup(ctx context.Context, userId uint64, id uint64, post StandupPostBody) (st Standup, notFound bool, outOfRange bool, err error) {
+	defer metrics.ObserveStore("PostStandup", time.Now(), &err)
+
+	// Check permission
+	s, notFound, err := Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+
+	// Check date
+	// Today in the time zone of the sprint
+	date := today(s.location(defaultLocation))
+	if post.Date != nil {
+		date = *post.Date
+	}
+	date, ok, err := withinSprint(s, date)
+	if err != nil {
+		return
+	}
+	if !ok {
+		outOfRange = true
+		return
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	queryStr := "INSERT INTO sprint_standups (sprint_id, user_id, date, text, blockers, updated_at) VALUES (?, ?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE text = VALUES(text), blockers = VALUES(blockers), updated_at = VALUES(updated_at)"
+	ctx, span := tracing.StartSQL(ctx, "INSERT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtIns, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtIns.Close()
+	updatedAt := time.Now().UTC().Format(datetimeLayout)
+	_, err = stmtIns.ExecContext(ctx, id, userId, date, post.Text, post.Blockers, updatedAt)
+	if err != nil {
+		return
+	}
+
+	st.UserId = userId
+	st.Date = date
+	st.Text = post.Text
+	st.Blockers = post.Blockers
+	st.UpdatedAt = formatDatetime(updatedAt)
+	return
+}
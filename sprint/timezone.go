@@ -0,0 +1,141 @@
+package sprint
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/go-playground/validator"
+)
+
+// Time zone of sprints without a time zone of their project or owner
+var defaultLocation = time.UTC
+
+// SetDefaultTimeZone sets the time zone of sprints whose project and owner have no time zone.
+func SetDefaultTimeZone(name string) error {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return err
+	}
+	defaultLocation = loc
+	return nil
+}
+
+// Join resolving the time zone of each sprint: the time zone of its project, otherwise of its owner
+const zoneJoin = " LEFT JOIN project_settings ps ON ps.project_id = s.project_id LEFT JOIN user_settings us ON us.user_id = s.user_id"
+const zoneColumn = ", COALESCE(ps.time_zone, us.time_zone)"
+
+type PatchNullJSONTime struct {
+	String **string `validate:"omitempty,H:M"`
+}
+
+func (p *PatchNullJSONTime) UnmarshalJSON(data []byte) error {
+	var tmp PatchNullJSONString
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+	p.String = tmp.String
+	return nil
+}
+
+func TimeOfDayStrValidation(fl validator.FieldLevel) bool {
+	// `hh:mm`
+	_, err := time.Parse("15:04", fl.Field().String())
+	return err == nil
+}
+
+// `hh:mm` -> TIME column
+func timeOfDayColumn(s *string) (*string, error) {
+	if s == nil {
+		return nil, nil
+	}
+	t, err := time.Parse("15:04", *s)
+	if err != nil {
+		return nil, err
+	}
+	c := t.Format("15:04:05")
+	return &c, nil
+}
+
+// TIME column -> `hh:mm`
+func formatTimeOfDay(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	t, err := time.Parse("15:04:05", *s)
+	if err != nil {
+		return s
+	}
+	f := t.Format("15:04")
+	return &f
+}
+
+// Location of the sprint, falling back to def if neither its project nor its owner has a time zone
+func (s Sprint) location(def *time.Location) *time.Location {
+	if s.zone != nil {
+		if loc, err := time.LoadLocation(*s.zone); err == nil {
+			return loc
+		}
+	}
+	return def
+}
+
+// Resolve `time_zone`, `starts_at` and `ends_at` of the sprint in its location.
+// Without `start_time` the sprint starts at the beginning of `start`,
+// without `end_time` it ends at the end of `end`.
+// Times skipped or repeated by DST transitions are normalized by time.Date.
+func (s *Sprint) resolveTimes(def *time.Location) error {
+	loc := s.location(def)
+	startsAt, endsAt, err := boundaries(s.Start, s.End, s.StartTime, s.EndTime, loc)
+	if err != nil {
+		return err
+	}
+	s.TimeZone = loc.String()
+	s.StartsAt = startsAt.Format(time.RFC3339)
+	s.EndsAt = endsAt.Format(time.RFC3339)
+	s.startsAt = startsAt
+	s.endsAt = endsAt
+	return nil
+}
+
+// Start and (exclusive) end of a sprint in loc
+func boundaries(start string, end string, startTime *string, endTime *string, loc *time.Location) (startsAt time.Time, endsAt time.Time, err error) {
+	startDate, err := time.Parse("2006-1-2", start)
+	if err != nil {
+		return
+	}
+	endDate, err := time.Parse("2006-1-2", end)
+	if err != nil {
+		return
+	}
+	startsAt = time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, loc)
+	if startTime != nil {
+		t, err := time.Parse("15:04", *startTime)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		startsAt = time.Date(startDate.Year(), startDate.Month(), startDate.Day(), t.Hour(), t.Minute(), 0, 0, loc)
+	}
+	endsAt = time.Date(endDate.Year(), endDate.Month(), endDate.Day()+1, 0, 0, 0, 0, loc)
+	if endTime != nil {
+		t, err := time.Parse("15:04", *endTime)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		endsAt = time.Date(endDate.Year(), endDate.Month(), endDate.Day(), t.Hour(), t.Minute(), 0, 0, loc)
+	}
+	return
+}
+
+// Whether the sprint would end before it starts (zone independent)
+func endsBeforeStart(start string, end string, startTime *string, endTime *string) (bool, error) {
+	startsAt, endsAt, err := boundaries(start, end, startTime, endTime, time.UTC)
+	if err != nil {
+		return false, err
+	}
+	return !startsAt.Before(endsAt), nil
+}
+
+// Today as `yyyy-mm-dd` in loc
+func today(loc *time.Location) string {
+	return time.Now().In(loc).Format("2006-01-02")
+}
@@ -0,0 +1,167 @@
+package sprint
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"math"
+	"time"
+)
+
+type VelocityQuery struct {
+	ProjectId *uint64 `query:"project_id" validate:"required,gte=1"`
+	Last      *uint64 `query:"last" validate:"omitempty,gte=1,lte=50"`
+	Window    *uint64 `query:"window" validate:"omitempty,gte=1,lte=50"`
+	Start     *string `query:"start" validate:"omitempty,Y-M-D"`
+	End       *string `query:"end" validate:"omitempty,Y-M-D"`
+}
+
+type Velocity struct {
+	ProjectId uint64           `json:"project_id"`
+	Sprints   []VelocitySprint `json:"sprints"`
+	// Average and standard deviation of the completed points of the sprints
+	Average float64 `json:"average"`
+	StdDev  float64 `json:"stddev"`
+}
+
+type VelocitySprint struct {
+	Id    uint64 `json:"id"`
+	Name  string `json:"name"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// Points in the sprint when it started, as in its burndown
+	CommittedPoints   float64 `json:"committed_points"`
+	CompletedPoints   float64 `json:"completed_points"`
+	CarriedOverPoints float64 `json:"carried_over_points"`
+	CompletedItems    uint64  `json:"completed_items"`
+	// Average completed points of the sprint and the previous ones in the window
+	RollingAverage float64 `json:"rolling_average"`
+}
+
+// Default number of sprints of the velocity report and of the rolling average
+const (
+	velocityDefaultLast   = 5
+	velocityDefaultWindow = 3
+)
+
+// GetVelocity returns the committed and completed points of the last finished sprints of the project
+// visible to userId, oldest first. `start` and `end` filter the sprints like GetList.
+// Whether a sprint has finished is evaluated in its own time zone.
+func GetVelocity(ctx context.Context, userId uint64, q VelocityQuery) (v Velocity, err error) {
+	defer metrics.ObserveStore("GetVelocity", time.Now(), &err)
+
+	last := uint64(velocityDefaultLast)
+	if q.Last != nil {
+		last = *q.Last
+	}
+	window := velocityDefaultWindow
+	if q.Window != nil {
+		window = int(*q.Window)
+	}
+
+	// Candidates: local dates differ from UTC dates by at most one day
+	now := time.Now()
+	dayAfter := now.UTC().AddDate(0, 0, 1).Format("2006-01-02")
+	queryParams := []interface{}{userId, userId, q.ProjectId, dayAfter}
+	where := " WHERE s.project_id = ? AND s.end <= ?"
+	if q.Start != nil {
+		where += " AND s.end >= ?"
+		queryParams = append(queryParams, q.Start)
+	}
+	if q.End != nil {
+		where += " AND s.start <= ?"
+		queryParams = append(queryParams, q.End)
+	}
+	queryParams = append(queryParams, userId)
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	queryStr := "SELECT s.id, s.name, s.start, s.end, s.start_time, s.end_time, s.project_id, s.user_id" + itemsColumns + zoneColumn + ", COALESCE(co.points, 0) FROM sprints s" + accessJoin + itemsJoin + zoneJoin +
+		" LEFT JOIN (SELECT from_sprint_id, SUM(estimate) AS points FROM sprint_carry_overs WHERE mode = 'move' GROUP BY from_sprint_id) co ON co.from_sprint_id = s.id" +
+		where + " AND" + accessWhere + " ORDER BY s.start DESC, s.id DESC"
+	ctx, span := tracing.Start(ctx, "GetVelocity")
+	defer func() { tracing.End(span, err) }()
+	rows, err := tracing.Query(ctx, db, queryStr, queryParams...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	v.ProjectId = *q.ProjectId
+	v.Sprints = []VelocitySprint{}
+	var sprints []Sprint
+	var ids []uint64
+	for rows.Next() {
+		s := Sprint{}
+		vs := VelocitySprint{}
+		err = rows.Scan(&s.Id, &s.Name, &s.Start, &s.End, &s.StartTime, &s.EndTime, &s.ProjectId, &s.UserId, &s.ItemCount, &s.DoneCount, &s.Points, &s.DonePoints, &s.zone, &vs.CarriedOverPoints)
+		if err != nil {
+			return
+		}
+		s.StartTime = formatTimeOfDay(s.StartTime)
+		s.EndTime = formatTimeOfDay(s.EndTime)
+		err = s.resolveTimes(defaultLocation)
+		if err != nil {
+			return
+		}
+		if s.endsAt.After(now) {
+			// Not finished
+			continue
+		}
+		vs.Id = s.Id
+		vs.Name = s.Name
+		vs.Start = s.Start
+		vs.End = s.End
+		vs.CompletedPoints = s.DonePoints
+		vs.CompletedItems = s.DoneCount
+		// Oldest first
+		sprints = append([]Sprint{s}, sprints...)
+		v.Sprints = append([]VelocitySprint{vs}, v.Sprints...)
+		ids = append(ids, s.Id)
+		if uint64(len(ids)) == last {
+			break
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return
+	}
+	if len(v.Sprints) == 0 {
+		return
+	}
+
+	// Committed points from the recorded item events
+	events, err := getItemEvents(ctx, ids)
+	if err != nil {
+		return
+	}
+	for i, s := range sprints {
+		v.Sprints[i].CommittedPoints = committedPoints(s, events[s.Id])
+	}
+
+	sum := 0.0
+	for i := range v.Sprints {
+		sum += v.Sprints[i].CompletedPoints
+
+		// Rolling average of the sprints in the window ending at i
+		from := i - window + 1
+		if from < 0 {
+			from = 0
+		}
+		windowSum := 0.0
+		for _, ws := range v.Sprints[from : i+1] {
+			windowSum += ws.CompletedPoints
+		}
+		v.Sprints[i].RollingAverage = windowSum / float64(i+1-from)
+	}
+	v.Average = sum / float64(len(v.Sprints))
+
+	variance := 0.0
+	for _, vs := range v.Sprints {
+		variance += (vs.CompletedPoints - v.Average) * (vs.CompletedPoints - v.Average)
+	}
+	v.StdDev = math.Sqrt(variance / float64(len(v.Sprints)))
+	return
+}
@@ -0,0 +1,43 @@
+package team
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// Delete deletes the team. Only owners can delete it.
+// Sprints of the team become personal sprints of their creators.
+func Delete(ctx context.Context, userId uint64, id uint64) (notFound bool, forbidden bool, err error) {
+	defer metrics.ObserveStore("team.Delete", time.Now(), &err)
+
+	// Check permission
+	t, notFound, err := Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+	if t.Role != RoleOwner {
+		return false, true, nil
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return false, false, err
+	}
+	queryStr := "DELETE FROM teams WHERE id = ?"
+	ctx, span := tracing.StartSQL(ctx, "DELETE", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtIns, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return false, false, err
+	}
+	defer stmtIns.Close()
+	_, err = stmtIns.ExecContext(ctx, id)
+	if err != nil {
+		return false, false, err
+	}
+
+	return false, false, nil
+}
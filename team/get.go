@@ -0,0 +1,46 @@
+package team
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// Get returns the team if userId is a member of it.
+func Get(ctx context.Context, userId uint64, id uint64) (t Team, notFound bool, err error) {
+	defer metrics.ObserveStore("team.Get", time.Now(), &err)
+
+	db, err := mysql.Open()
+	if err != nil {
+		return Team{}, false, err
+	}
+
+	queryStr := "SELECT t.name, tm.role FROM teams t JOIN team_members tm ON tm.team_id = t.id WHERE t.id = ? AND tm.user_id = ?"
+	ctx, span := tracing.StartSQL(ctx, "SELECT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtOut, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return Team{}, false, err
+	}
+	defer stmtOut.Close()
+
+	rows, err := stmtOut.QueryContext(ctx, id, userId)
+	if err != nil {
+		return Team{}, false, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		// Not found
+		return Team{}, true, nil
+	}
+	err = rows.Scan(&t.Name, &t.Role)
+	if err != nil {
+		return Team{}, false, err
+	}
+
+	t.Id = id
+	return
+}
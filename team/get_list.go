@@ -0,0 +1,45 @@
+package team
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// GetList returns teams userId is a member of.
+func GetList(ctx context.Context, userId uint64) (teams []Team, err error) {
+	defer metrics.ObserveStore("team.GetList", time.Now(), &err)
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+
+	queryStr := "SELECT t.id, t.name, tm.role FROM teams t JOIN team_members tm ON tm.team_id = t.id WHERE tm.user_id = ? ORDER BY t.id"
+	ctx, span := tracing.StartSQL(ctx, "SELECT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtOut, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtOut.Close()
+
+	rows, err := stmtOut.QueryContext(ctx, userId)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		t := Team{}
+		err = rows.Scan(&t.Id, &t.Name, &t.Role)
+		if err != nil {
+			return
+		}
+		teams = append(teams, t)
+	}
+
+	return
+}
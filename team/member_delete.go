@@ -0,0 +1,60 @@
+package team
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// DeleteMember removes memberUserId from the team.
+// Owners and admins can remove members (only owners can remove owners), and anyone can leave.
+// lastOwner is true if the change would leave the team without an owner.
+func DeleteMember(ctx context.Context, userId uint64, id uint64, memberUserId uint64) (notFound bool, forbidden bool, lastOwner bool, err error) {
+	defer metrics.ObserveStore("team.DeleteMember", time.Now(), &err)
+
+	// Check permission
+	t, notFound, err := Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+	current, isMember, err := MemberRole(ctx, id, memberUserId)
+	if err != nil {
+		return
+	}
+	if !isMember {
+		return true, false, false, nil
+	}
+	if userId != memberUserId && (!CanManage(t.Role) || (current == RoleOwner && t.Role != RoleOwner)) {
+		return false, true, false, nil
+	}
+	if current == RoleOwner {
+		owners, err := countOwners(ctx, id)
+		if err != nil {
+			return false, false, false, err
+		}
+		if owners <= 1 {
+			return false, false, true, nil
+		}
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return false, false, false, err
+	}
+	queryStr := "DELETE FROM team_members WHERE team_id = ? AND user_id = ?"
+	ctx, span := tracing.StartSQL(ctx, "DELETE", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtIns, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return false, false, false, err
+	}
+	defer stmtIns.Close()
+	_, err = stmtIns.ExecContext(ctx, id, memberUserId)
+	if err != nil {
+		return false, false, false, err
+	}
+
+	return false, false, false, nil
+}
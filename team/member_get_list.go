@@ -0,0 +1,51 @@
+package team
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// GetMemberList returns members of the team if userId is a member of it.
+func GetMemberList(ctx context.Context, userId uint64, id uint64) (members []Member, notFound bool, err error) {
+	defer metrics.ObserveStore("team.GetMemberList", time.Now(), &err)
+
+	// Check permission
+	_, notFound, err = Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+
+	queryStr := "SELECT user_id, role FROM team_members WHERE team_id = ? ORDER BY user_id"
+	ctx, span := tracing.StartSQL(ctx, "SELECT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtOut, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtOut.Close()
+
+	rows, err := stmtOut.QueryContext(ctx, id)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		m := Member{}
+		err = rows.Scan(&m.UserId, &m.Role)
+		if err != nil {
+			return
+		}
+		members = append(members, m)
+	}
+
+	return
+}
@@ -0,0 +1,69 @@
+package team
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+type MemberPutBody struct {
+	Role string `json:"role" validate:"required,oneof=owner admin member"`
+}
+
+// PutMember adds memberUserId to the team, or changes the role if already a member.
+// Owners and admins can manage members, but only owners can grant or revoke `owner`.
+// lastOwner is true if the change would leave the team without an owner.
+func PutMember(ctx context.Context, userId uint64, id uint64, memberUserId uint64, put MemberPutBody) (m Member, notFound bool, forbidden bool, lastOwner bool, err error) {
+	defer metrics.ObserveStore("team.PutMember", time.Now(), &err)
+
+	// Check permission
+	t, notFound, err := Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+	if !CanManage(t.Role) {
+		forbidden = true
+		return
+	}
+	current, isMember, err := MemberRole(ctx, id, memberUserId)
+	if err != nil {
+		return
+	}
+	if (put.Role == RoleOwner || (isMember && current == RoleOwner)) && t.Role != RoleOwner {
+		forbidden = true
+		return
+	}
+	if isMember && current == RoleOwner && put.Role != RoleOwner {
+		owners, err := countOwners(ctx, id)
+		if err != nil {
+			return Member{}, false, false, false, err
+		}
+		if owners <= 1 {
+			lastOwner = true
+			return Member{}, false, false, true, nil
+		}
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	queryStr := "INSERT INTO team_members (team_id, user_id, role) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE role = VALUES(role)"
+	ctx, span := tracing.StartSQL(ctx, "INSERT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtIns, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtIns.Close()
+	_, err = stmtIns.ExecContext(ctx, id, memberUserId, put.Role)
+	if err != nil {
+		return
+	}
+
+	m.UserId = memberUserId
+	m.Role = put.Role
+	return
+}
@@ -0,0 +1,48 @@
+package team
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// MemberRole returns the role of userId in the team. isMember is false if not a member.
+func MemberRole(ctx context.Context, id uint64, userId uint64) (role string, isMember bool, err error) {
+	defer metrics.ObserveStore("team.MemberRole", time.Now(), &err)
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+
+	queryStr := "SELECT role FROM team_members WHERE team_id = ? AND user_id = ?"
+	ctx, span := tracing.StartSQL(ctx, "SELECT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	rows, err := db.QueryContext(ctx, queryStr, id, userId)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		// Not a member
+		return "", false, nil
+	}
+	err = rows.Scan(&role)
+	return role, err == nil, err
+}
+
+func countOwners(ctx context.Context, id uint64) (count uint64, err error) {
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+
+	queryStr := "SELECT COUNT(*) FROM team_members WHERE team_id = ? AND role = ?"
+	ctx, span := tracing.StartSQL(ctx, "SELECT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	err = db.QueryRowContext(ctx, queryStr, id, RoleOwner).Scan(&count)
+	return
+}
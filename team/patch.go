@@ -0,0 +1,51 @@
+package team
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+type PatchBody struct {
+	Name *string `json:"name" validate:"omitempty,gte=1"`
+}
+
+// Patch renames the team. Owners and admins can rename it.
+func Patch(ctx context.Context, userId uint64, id uint64, new PatchBody) (t Team, notFound bool, forbidden bool, err error) {
+	defer metrics.ObserveStore("team.Patch", time.Now(), &err)
+
+	// Get old
+	t, notFound, err = Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+	if !CanManage(t.Role) {
+		forbidden = true
+		return
+	}
+	if new.Name == nil {
+		return
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	queryStr := "UPDATE teams SET name = ? WHERE id = ?"
+	ctx, span := tracing.StartSQL(ctx, "UPDATE", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtIns, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtIns.Close()
+	_, err = stmtIns.ExecContext(ctx, new.Name, id)
+	if err != nil {
+		return
+	}
+
+	t.Name = *new.Name
+	return
+}
@@ -0,0 +1,51 @@
+package team
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+type PostBody struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// Post creates a team whose owner is userId.
+func Post(ctx context.Context, userId uint64, post PostBody) (t Team, err error) {
+	defer metrics.ObserveStore("team.Post", time.Now(), &err)
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	ctx, span := tracing.Start(ctx, "team.Post")
+	defer func() { tracing.End(span, err) }()
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	result, err := tracing.Exec(ctx, tx, "INSERT INTO teams (name) VALUES (?)", post.Name)
+	if err != nil {
+		return
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return
+	}
+	_, err = tracing.Exec(ctx, tx, "INSERT INTO team_members (team_id, user_id, role) VALUES (?, ?, ?)", id, userId, RoleOwner)
+	if err != nil {
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		return
+	}
+
+	t.Id = uint64(id)
+	t.Name = post.Name
+	t.Role = RoleOwner
+	return
+}
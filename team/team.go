@@ -0,0 +1,25 @@
+package team
+
+// Roles of team members
+const (
+	RoleOwner  = "owner"
+	RoleAdmin  = "admin"
+	RoleMember = "member"
+)
+
+type Team struct {
+	Id   uint64 `json:"id"`
+	Name string `json:"name"`
+	// Role of the requesting user
+	Role string `json:"role"`
+}
+
+type Member struct {
+	UserId uint64 `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+// CanManage reports whether role can rename the team and manage its members.
+func CanManage(role string) bool {
+	return role == RoleOwner || role == RoleAdmin
+}
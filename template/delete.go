@@ -0,0 +1,37 @@
+package template
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// Delete deletes the template of userId.
+func Delete(ctx context.Context, userId uint64, id uint64) (notFound bool, err error) {
+	defer metrics.ObserveStore("template.Delete", time.Now(), &err)
+
+	db, err := mysql.Open()
+	if err != nil {
+		return false, err
+	}
+	queryStr := "DELETE FROM sprint_templates WHERE id = ? AND user_id = ?"
+	ctx, span := tracing.StartSQL(ctx, "DELETE", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtIns, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return false, err
+	}
+	defer stmtIns.Close()
+	result, err := stmtIns.ExecContext(ctx, id, userId)
+	if err != nil {
+		return false, err
+	}
+	affectedRowCount, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affectedRowCount == 0, nil
+}
@@ -0,0 +1,52 @@
+package template
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/sprint"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// Get returns the template if it belongs to userId.
+func Get(ctx context.Context, userId uint64, id uint64) (t Template, notFound bool, err error) {
+	defer metrics.ObserveStore("template.Get", time.Now(), &err)
+
+	db, err := mysql.Open()
+	if err != nil {
+		return Template{}, false, err
+	}
+
+	queryStr := "SELECT name, name_pattern, length, description, goals, project_id FROM sprint_templates WHERE id = ? AND user_id = ?"
+	ctx, span := tracing.StartSQL(ctx, "SELECT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtOut, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return Template{}, false, err
+	}
+	defer stmtOut.Close()
+
+	rows, err := stmtOut.QueryContext(ctx, id, userId)
+	if err != nil {
+		return Template{}, false, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		// Not found
+		return Template{}, true, nil
+	}
+	var goals *string
+	err = rows.Scan(&t.Name, &t.NamePattern, &t.Length, &t.Description, &goals, &t.ProjectId)
+	if err != nil {
+		return Template{}, false, err
+	}
+	t.Goals, err = sprint.DecodeGoals(goals)
+	if err != nil {
+		return Template{}, false, err
+	}
+
+	t.Id = id
+	return
+}
@@ -0,0 +1,51 @@
+package template
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/sprint"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// GetList returns the templates of userId.
+func GetList(ctx context.Context, userId uint64) (templates []Template, err error) {
+	defer metrics.ObserveStore("template.GetList", time.Now(), &err)
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+
+	queryStr := "SELECT id, name, name_pattern, length, description, goals, project_id FROM sprint_templates WHERE user_id = ? ORDER BY id"
+	ctx, span := tracing.StartSQL(ctx, "SELECT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtOut, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtOut.Close()
+
+	rows, err := stmtOut.QueryContext(ctx, userId)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		t := Template{}
+		var goals *string
+		err = rows.Scan(&t.Id, &t.Name, &t.NamePattern, &t.Length, &t.Description, &goals, &t.ProjectId)
+		if err != nil {
+			return
+		}
+		t.Goals, err = sprint.DecodeGoals(goals)
+		if err != nil {
+			return
+		}
+		templates = append(templates, t)
+	}
+
+	return
+}
@@ -0,0 +1,69 @@
+package template
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/sprint"
+	"flow-sprints/tracing"
+	"time"
+)
+
+type PatchBody struct {
+	Name        *string                    `json:"name" validate:"omitempty,gte=1"`
+	NamePattern *string                    `json:"name_pattern" validate:"omitempty,gte=1"`
+	Length      *uint64                    `json:"length" validate:"omitempty,gte=1,lte=366"`
+	Description sprint.PatchNullJSONString `json:"description" validate:"omitempty"`
+	Goals       *[]string                  `json:"goals" validate:"omitempty,dive,required"`
+	ProjectId   sprint.PatchNullJSONUint64 `json:"project_id" validate:"dive"`
+}
+
+// Patch updates the template of userId.
+func Patch(ctx context.Context, userId uint64, id uint64, new PatchBody) (t Template, notFound bool, err error) {
+	defer metrics.ObserveStore("template.Patch", time.Now(), &err)
+
+	// Get old
+	t, notFound, err = Get(ctx, userId, id)
+	if err != nil || notFound {
+		return
+	}
+
+	// Set no update values
+	if new.Name != nil {
+		t.Name = *new.Name
+	}
+	if new.NamePattern != nil {
+		t.NamePattern = *new.NamePattern
+	}
+	if new.Length != nil {
+		t.Length = *new.Length
+	}
+	if new.Description.String != nil {
+		t.Description = *new.Description.String
+	}
+	if new.Goals != nil {
+		t.Goals = *new.Goals
+	}
+	if new.ProjectId.UInt64 != nil {
+		t.ProjectId = *new.ProjectId.UInt64
+	}
+	goals, err := sprint.EncodeGoals(t.Goals)
+	if err != nil {
+		return
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	queryStr := "UPDATE sprint_templates SET name = ?, name_pattern = ?, length = ?, description = ?, goals = ?, project_id = ? WHERE id = ? AND user_id = ?"
+	ctx, span := tracing.StartSQL(ctx, "UPDATE", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtIns, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtIns.Close()
+	_, err = stmtIns.ExecContext(ctx, t.Name, t.NamePattern, t.Length, t.Description, goals, t.ProjectId, id, userId)
+	return
+}
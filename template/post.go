@@ -0,0 +1,59 @@
+package template
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/sprint"
+	"flow-sprints/tracing"
+	"time"
+)
+
+type PostBody struct {
+	Name        string   `json:"name" validate:"required"`
+	NamePattern string   `json:"name_pattern" validate:"required"`
+	Length      uint64   `json:"length" validate:"required,gte=1,lte=366"`
+	Description *string  `json:"description" validate:"omitempty"`
+	Goals       []string `json:"goals" validate:"omitempty,dive,required"`
+	ProjectId   *uint64  `json:"project_id" validate:"omitempty,gte=1"`
+}
+
+// Post creates a template of userId.
+func Post(ctx context.Context, userId uint64, post PostBody) (t Template, err error) {
+	defer metrics.ObserveStore("template.Post", time.Now(), &err)
+
+	goals, err := sprint.EncodeGoals(post.Goals)
+	if err != nil {
+		return
+	}
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	queryStr := "INSERT INTO sprint_templates (user_id, name, name_pattern, length, description, goals, project_id) VALUES (?, ?, ?, ?, ?, ?, ?)"
+	ctx, span := tracing.StartSQL(ctx, "INSERT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtIns, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtIns.Close()
+	result, err := stmtIns.ExecContext(ctx, userId, post.Name, post.NamePattern, post.Length, post.Description, goals, post.ProjectId)
+	if err != nil {
+		return
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return
+	}
+
+	t.Id = uint64(id)
+	t.Name = post.Name
+	t.NamePattern = post.NamePattern
+	t.Length = post.Length
+	t.Description = post.Description
+	t.Goals = post.Goals
+	t.ProjectId = post.ProjectId
+	return
+}
@@ -0,0 +1,51 @@
+package template
+
+import (
+	"flow-sprints/sprint"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Template struct {
+	Id   uint64 `json:"id"`
+	Name string `json:"name"`
+	// Name of sprints created from the template.
+	// `{start}`, `{end}`, `{year}` and `{week}` (ISO week of `start`) are replaced.
+	NamePattern string   `json:"name_pattern"`
+	Length      uint64   `json:"length"`
+	Description *string  `json:"description,omitempty"`
+	Goals       []string `json:"goals,omitempty"`
+	ProjectId   *uint64  `json:"project_id,omitempty"`
+}
+
+// Apply fills the fields of the sprint that are not given from the template.
+// `end` defaults to `start` + `length` - 1 days.
+// Nothing is applied without a valid `start`, which is left to the validation of the sprint.
+func (t Template) Apply(post *sprint.PostBody) {
+	start, err := time.Parse("2006-1-2", post.Start)
+	if err != nil {
+		return
+	}
+	if post.End == "" {
+		post.End = start.AddDate(0, 0, int(t.Length)-1).Format("2006-01-02")
+	}
+	if post.Name == "" {
+		year, week := start.ISOWeek()
+		post.Name = strings.NewReplacer(
+			"{start}", start.Format("2006-01-02"),
+			"{end}", post.End,
+			"{year}", strconv.Itoa(year),
+			"{week}", strconv.Itoa(week),
+		).Replace(t.NamePattern)
+	}
+	if post.Description == nil {
+		post.Description = t.Description
+	}
+	if post.Goals == nil {
+		post.Goals = t.Goals
+	}
+	if post.ProjectId == nil {
+		post.ProjectId = t.ProjectId
+	}
+}
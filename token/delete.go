@@ -0,0 +1,37 @@
+package token
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// Delete revokes the token.
+func Delete(ctx context.Context, userId uint64, id uint64) (notFound bool, err error) {
+	defer metrics.ObserveStore("token.Delete", time.Now(), &err)
+
+	db, err := mysql.Open()
+	if err != nil {
+		return false, err
+	}
+	queryStr := "DELETE FROM personal_access_tokens WHERE user_id = ? AND id = ?"
+	ctx, span := tracing.StartSQL(ctx, "DELETE", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtIns, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return false, err
+	}
+	defer stmtIns.Close()
+	result, err := stmtIns.ExecContext(ctx, userId, id)
+	if err != nil {
+		return false, err
+	}
+	affectedRowCount, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affectedRowCount == 0, nil
+}
@@ -0,0 +1,49 @@
+package token
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+func GetList(ctx context.Context, userId uint64) (tokens []Token, err error) {
+	defer metrics.ObserveStore("token.GetList", time.Now(), &err)
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+
+	queryStr := "SELECT id, name, scopes, expires_at, last_used_at, created_at FROM personal_access_tokens WHERE user_id = ? ORDER BY id"
+	ctx, span := tracing.StartSQL(ctx, "SELECT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtOut, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtOut.Close()
+
+	rows, err := stmtOut.QueryContext(ctx, userId)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		t := Token{}
+		var scopes *string
+		err = rows.Scan(&t.Id, &t.Name, &scopes, &t.ExpiresAt, &t.LastUsedAt, &t.CreatedAt)
+		if err != nil {
+			return
+		}
+		t.Scopes = splitScopes(scopes)
+		t.ExpiresAt = formatDatetime(t.ExpiresAt)
+		t.LastUsedAt = formatDatetime(t.LastUsedAt)
+		t.CreatedAt = *formatDatetime(&t.CreatedAt)
+		tokens = append(tokens, t)
+	}
+
+	return
+}
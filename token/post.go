@@ -0,0 +1,83 @@
+package token
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+
+	"github.com/go-playground/validator"
+)
+
+type PostBody struct {
+	Name      string   `json:"name" validate:"required"`
+	Scopes    []string `json:"scopes" validate:"omitempty,dive,oneof=sprints:read sprints:write sprints:delete-all"`
+	ExpiresAt *string  `json:"expires_at" validate:"omitempty,RFC3339"`
+}
+
+type PostResult struct {
+	Token
+	// Shown only once at creation
+	Raw string `json:"token"`
+}
+
+func DatetimeStrValidation(fl validator.FieldLevel) bool {
+	// RFC3339
+	_, err := time.Parse(time.RFC3339, fl.Field().String())
+	return err == nil
+}
+
+func Post(ctx context.Context, userId uint64, post PostBody) (p PostResult, expired bool, err error) {
+	defer metrics.ObserveStore("token.Post", time.Now(), &err)
+
+	// Check expiry
+	var expiresAt *string
+	if post.ExpiresAt != nil {
+		t, err := time.Parse(time.RFC3339, *post.ExpiresAt)
+		if err != nil {
+			return PostResult{}, false, err
+		}
+		if !t.After(time.Now()) {
+			return PostResult{}, true, nil
+		}
+		e := t.UTC().Format(datetimeLayout)
+		expiresAt = &e
+	}
+
+	raw, hash, err := generate()
+	if err != nil {
+		return
+	}
+	createdAt := time.Now().UTC().Format(datetimeLayout)
+
+	// Insert DB
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+	queryStr := "INSERT INTO personal_access_tokens (user_id, name, token_hash, scopes, expires_at, created_at) VALUES (?, ?, ?, ?, ?, ?)"
+	ctx, span := tracing.StartSQL(ctx, "INSERT", queryStr)
+	defer func() { tracing.End(span, err) }()
+	stmtIns, err := db.PrepareContext(ctx, queryStr)
+	if err != nil {
+		return
+	}
+	defer stmtIns.Close()
+	result, err := stmtIns.ExecContext(ctx, userId, post.Name, hash, joinScopes(post.Scopes), expiresAt, createdAt)
+	if err != nil {
+		return
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return
+	}
+
+	p.Id = uint64(id)
+	p.Name = post.Name
+	p.Scopes = post.Scopes
+	p.ExpiresAt = formatDatetime(expiresAt)
+	p.CreatedAt = *formatDatetime(&createdAt)
+	p.Raw = raw
+	return
+}
@@ -0,0 +1,69 @@
+package token
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// Prefix of personal access tokens, used to tell them from JWTs.
+const Prefix = "fst_"
+
+// Layout of DATETIME columns
+const datetimeLayout = "2006-01-02 15:04:05"
+
+type Token struct {
+	Id         uint64   `json:"id"`
+	Name       string   `json:"name"`
+	Scopes     []string `json:"scopes,omitempty"`
+	ExpiresAt  *string  `json:"expires_at,omitempty"`
+	LastUsedAt *string  `json:"last_used_at,omitempty"`
+	CreatedAt  string   `json:"created_at"`
+}
+
+// Generate a random token and its hash to store
+func generate() (raw string, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err = rand.Read(b); err != nil {
+		return
+	}
+	raw = Prefix + base64.RawURLEncoding.EncodeToString(b)
+	return raw, hashToken(raw), nil
+}
+
+func hashToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// `scopes` column <-> scopes
+func joinScopes(scopes []string) *string {
+	if len(scopes) == 0 {
+		return nil
+	}
+	s := strings.Join(scopes, " ")
+	return &s
+}
+
+func splitScopes(s *string) []string {
+	if s == nil {
+		return nil
+	}
+	return strings.Fields(*s)
+}
+
+// DATETIME (UTC) -> RFC3339
+func formatDatetime(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	t, err := time.Parse(datetimeLayout, *s)
+	if err != nil {
+		return s
+	}
+	f := t.UTC().Format(time.RFC3339)
+	return &f
+}
@@ -0,0 +1,56 @@
+package token
+
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/mysql"
+	"flow-sprints/tracing"
+	"time"
+)
+
+// Verify looks up the token by its hash.
+// notFound is true if the token does not exist or has expired.
+func Verify(ctx context.Context, raw string) (id uint64, userId uint64, scopes []string, expiresAt *time.Time, notFound bool, err error) {
+	defer metrics.ObserveStore("token.Verify", time.Now(), &err)
+
+	db, err := mysql.Open()
+	if err != nil {
+		return
+	}
+
+	ctx, span := tracing.Start(ctx, "token.Verify")
+	defer func() { tracing.End(span, err) }()
+	rows, err := tracing.Query(ctx, db, "SELECT id, user_id, scopes, expires_at FROM personal_access_tokens WHERE token_hash = ?", hashToken(raw))
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		// Not found
+		notFound = true
+		return
+	}
+	var scopesStr, expiresAtStr *string
+	err = rows.Scan(&id, &userId, &scopesStr, &expiresAtStr)
+	if err != nil {
+		return
+	}
+	scopes = splitScopes(scopesStr)
+
+	if expiresAtStr != nil {
+		t, err := time.Parse(datetimeLayout, *expiresAtStr)
+		if err != nil {
+			return 0, 0, nil, nil, false, err
+		}
+		if !t.After(time.Now()) {
+			// Expired
+			return 0, 0, nil, nil, true, nil
+		}
+		expiresAt = &t
+	}
+
+	// Record usage
+	_, err = tracing.Exec(ctx, db, "UPDATE personal_access_tokens SET last_used_at = ? WHERE id = ?", time.Now().UTC().Format(datetimeLayout), id)
+	return
+}
@@ -0,0 +1,189 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/labstack/echo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	serviceName = "flow-sprints"
+	tracerName  = "flow-sprints"
+)
+
+// Init sets up the global tracer provider and W3C trace context propagator.
+// exporter is one of "none", "stdout" or "otlp".
+// The returned function flushes and stops the exporter.
+func Init(exporter string, endpoint string, insecure bool) (shutdown func(context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exp sdktrace.SpanExporter
+	switch exporter {
+	case "", "none":
+		return func(context.Context) error { return nil }, nil
+	case "stdout":
+		exp, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case "otlp":
+		opts := []otlptracehttp.Option{}
+		if endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(endpoint))
+		}
+		if insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exp, err = otlptracehttp.New(context.Background(), opts...)
+	default:
+		return nil, fmt.Errorf("unknown trace exporter `%s`", exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.AlwaysSample())),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(serviceName))),
+	)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+func tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// Middleware starts a server span for each request, continuing the trace
+// propagated by the caller if any.
+func Middleware(skipper func(c echo.Context) bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if skipper != nil && skipper(c) {
+				return next(c)
+			}
+
+			req := c.Request()
+			ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+			ctx, span := tracer().Start(ctx, req.Method+" "+c.Path(),
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest(serviceName, c.Path(), req)...),
+			)
+			defer span.End()
+			c.SetRequest(req.WithContext(ctx))
+
+			err := next(c)
+
+			status := c.Response().Status
+			if err != nil {
+				var he *echo.HTTPError
+				if errors.As(err, &he) {
+					status = he.Code
+				} else if !c.Response().Committed {
+					status = http.StatusInternalServerError
+				}
+				span.RecordError(err)
+			}
+			span.SetAttributes(semconv.HTTPAttributesFromHTTPStatusCode(status)...)
+			span.SetStatus(semconv.SpanStatusFromHTTPStatusCodeAndSpanKind(status, trace.SpanKindServer))
+			return err
+		}
+	}
+}
+
+// StartSQL starts a client span for a SQL statement.
+func StartSQL(ctx context.Context, operation string, query string) (context.Context, trace.Span) {
+	return tracer().Start(ctx, "sql "+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemMySQL,
+			semconv.DBStatementKey.String(query),
+			semconv.DBOperationKey.String(operation),
+		),
+	)
+}
+
+// Start starts an internal span for an operation running several statements,
+// which are traced as its children with Exec, Query and QueryRow.
+func Start(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracer().Start(ctx, name, trace.WithSpanKind(trace.SpanKindInternal))
+}
+
+// DB is implemented by *sql.DB and *sql.Tx.
+type DB interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Exec executes a statement in its own span.
+func Exec(ctx context.Context, db DB, query string, args ...interface{}) (result sql.Result, err error) {
+	ctx, span := StartSQL(ctx, operation(query), query)
+	defer func() { End(span, err) }()
+	return db.ExecContext(ctx, query, args...)
+}
+
+// Query runs a query in its own span. The span ends when the rows are returned.
+func Query(ctx context.Context, db DB, query string, args ...interface{}) (rows *sql.Rows, err error) {
+	ctx, span := StartSQL(ctx, operation(query), query)
+	defer func() { End(span, err) }()
+	return db.QueryContext(ctx, query, args...)
+}
+
+// QueryRow runs a query returning at most one row in its own span.
+func QueryRow(ctx context.Context, db DB, query string, args ...interface{}) *sql.Row {
+	ctx, span := StartSQL(ctx, operation(query), query)
+	row := db.QueryRowContext(ctx, query, args...)
+	End(span, row.Err())
+	return row
+}
+
+// First keyword of the statement
+func operation(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToUpper(fields[0])
+}
+
+// StartHTTPClient starts a client span for an outbound request and injects
+// the trace context into its headers.
+func StartHTTPClient(req *http.Request) (*http.Request, trace.Span) {
+	ctx, span := tracer().Start(req.Context(), "HTTP "+req.Method+" "+req.URL.Host,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.HTTPClientAttributesFromHTTPRequest(req)...),
+	)
+	req = req.WithContext(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	return req, span
+}
+
+// End ends span, recording err if not nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// SetHTTPStatus records the response status of an outbound request.
+func SetHTTPStatus(span trace.Span, status int) {
+	span.SetAttributes(attribute.Int("http.status_code", status))
+	span.SetStatus(semconv.SpanStatusFromHTTPStatusCodeAndSpanKind(status, trace.SpanKindClient))
+}
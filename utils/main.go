@@ -1,9 +1,18 @@
 package utils
 
-import "net/http"
+import (
+	"context"
+	"flow-sprints/metrics"
+	"flow-sprints/tracing"
+	"net/http"
+)
 
 func HttpGet(url string, bearer *string) (status int, err error) {
-	req, err := http.NewRequest("GET", url, nil)
+	return HttpGetContext(context.Background(), url, bearer)
+}
+
+func HttpGetContext(ctx context.Context, url string, bearer *string) (status int, err error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return
 	}
@@ -11,13 +20,20 @@ func HttpGet(url string, bearer *string) (status int, err error) {
 		req.Header.Set("Authorization", "Bearer "+*bearer)
 	}
 
+	// Propagate trace context
+	req, span := tracing.StartHTTPClient(req)
+	defer func() { tracing.End(span, err) }()
+
 	client := new(http.Client)
 	res, err := client.Do(req)
 	if err != nil {
+		metrics.ObserveOutbound(req.URL.Host, 0)
 		return
 	}
 	defer res.Body.Close()
 
 	status = res.StatusCode
+	metrics.ObserveOutbound(req.URL.Host, status)
+	tracing.SetHTTPStatus(span, status)
 	return
 }